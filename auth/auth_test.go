@@ -0,0 +1,176 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/auth"
+	"github.com/stretchr/testify/assert"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+func TestJWTVerifier(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	jwks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: key.Public(), KeyID: "k1", Algorithm: "RS256", Use: "sig"}}}
+	b, _ := json.Marshal(jwks)
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		t.Fatalf("Failed to write JWKS: %v", err)
+	}
+
+	v, err := auth.NewJWTVerifier(path, "issuer", "customer-registry")
+	assert.Nil(t, err, "JWKS should load")
+
+	valid := jwt.Claims{
+		Issuer:   "issuer",
+		Subject:  "agent-007",
+		Audience: jwt.Audience{"customer-registry"},
+		Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+	expired := valid
+	expired.Expiry = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	wrongAudience := valid
+	wrongAudience.Audience = jwt.Audience{"billing"}
+	noExpiry := valid
+	noExpiry.Expiry = nil
+	longLived := valid
+	longLived.Expiry = jwt.NewNumericDate(time.Now().Add(auth.MaxTokenLifetime + time.Hour))
+
+	testCases := []struct {
+		desc   string
+		token  string
+		want   *auth.Principal
+		errMsg string
+	}{
+		{
+			desc:  "valid token",
+			token: sign(t, key, "k1", valid),
+			want:  &auth.Principal{Subject: "agent-007", Roles: []string{"call-centre"}, Permissions: []string{"registry:read", "registry:write"}},
+		},
+		{
+			desc:  "expired token",
+			token: sign(t, key, "k1", expired),
+		},
+		{
+			desc:  "no expiry",
+			token: sign(t, key, "k1", noExpiry),
+		},
+		{
+			desc:  "expiry beyond maximum lifetime",
+			token: sign(t, key, "k1", longLived),
+		},
+		{
+			desc:  "wrong audience",
+			token: sign(t, key, "k1", wrongAudience),
+		},
+		{
+			desc:  "unknown key",
+			token: sign(t, other, "k2", valid),
+		},
+		{
+			desc:  "wrong signature",
+			token: sign(t, other, "k1", valid),
+		},
+		{
+			desc:  "garbage",
+			token: "not-a-jwt",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := v.Verify(tC.token)
+
+			if tC.want != nil {
+				assert.Nil(t, err, "error should be nil")
+				assert.Equal(t, tC.want, got, "principal should equal")
+			} else {
+				assert.True(t, errors.Is(err, auth.ErrUnauthenticated), "Expecting ErrUnauthenticated, got: %v", err)
+				assert.Nil(t, got, "principal should be nil")
+			}
+		})
+	}
+}
+
+func TestRBAC(t *testing.T) {
+	t.Parallel()
+
+	rbac := auth.NewRBAC(map[string][]string{"admin": {"registry:admin"}})
+
+	testCases := []struct {
+		desc      string
+		principal *auth.Principal
+		perm      string
+		err       error
+	}{
+		{
+			desc: "no principal",
+			perm: "registry:read",
+			err:  auth.ErrUnauthenticated,
+		},
+		{
+			desc:      "granted directly",
+			principal: &auth.Principal{Subject: "s", Permissions: []string{"registry:read"}},
+			perm:      "registry:read",
+		},
+		{
+			desc:      "granted by role",
+			principal: &auth.Principal{Subject: "s", Roles: []string{"admin"}},
+			perm:      "registry:admin",
+		},
+		{
+			desc:      "denied",
+			principal: &auth.Principal{Subject: "s", Permissions: []string{"registry:read"}},
+			perm:      "registry:admin",
+			err:       auth.ErrPermissionDenied,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := context.Background()
+			if tC.principal != nil {
+				ctx = auth.NewContext(ctx, tC.principal)
+			}
+
+			err := rbac.Authorize(ctx, tC.perm)
+
+			assert.Truef(t, errors.Is(err, tC.err), "Expecting %v , got: %v", tC.err, err)
+		})
+	}
+}
+
+func sign(t *testing.T, key *rsa.PrivateKey, kid string, c jwt.Claims) string {
+	opts := (&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", kid)
+
+	s, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, opts)
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+
+	tok, err := jwt.Signed(s).Claims(c).Claims(map[string]interface{}{
+		"roles": []string{"call-centre"},
+		"scope": "registry:read registry:write",
+	}).CompactSerialize()
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+
+	return tok
+}
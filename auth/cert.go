@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"crypto/x509"
+
+	"github.com/cockroachdb/errors"
+)
+
+// CertPrincipal maps a verified client certificate to a principal, the common name is the
+// subject, the organization the tenant and organizational units are roles
+func CertPrincipal(cert *x509.Certificate) (*Principal, error) {
+	if cert == nil || cert.Subject.CommonName == "" {
+		return nil, errors.Mark(errors.New("auth.CertPrincipal: certificate without common name"), ErrUnauthenticated)
+	}
+
+	p := &Principal{
+		Subject: cert.Subject.CommonName,
+		Roles:   cert.Subject.OrganizationalUnit,
+	}
+
+	if len(cert.Subject.Organization) > 0 {
+		p.Tenant = cert.Subject.Organization[0]
+	}
+
+	return p, nil
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// MaxTokenLifetime bounds how far in the future a token may expire, longer lived tokens are
+// rejected
+const MaxTokenLifetime = 24 * time.Hour
+
+// claims are the registered claims plus roles and OAuth2 scope
+type claims struct {
+	jwt.Claims
+	Roles  []string `json:"roles"`
+	Scope  string   `json:"scope"`
+	Tenant string   `json:"tenant"`
+}
+
+// NewJWTVerifier verifies JWTs signed with a key from a local JWKS file
+func NewJWTVerifier(jwksPath, issuer, audience string) (*JWTVerifier, error) {
+	const op string = "auth.NewJWTVerifier"
+
+	b, err := ioutil.ReadFile(jwksPath)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	var jwks jose.JSONWebKeySet
+	if err := json.Unmarshal(b, &jwks); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return &JWTVerifier{jwks: jwks, issuer: issuer, audience: audience, now: time.Now}, nil
+}
+
+type JWTVerifier struct {
+	jwks     jose.JSONWebKeySet
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+func (v *JWTVerifier) Verify(token string) (*Principal, error) {
+	const op string = "auth.JWTVerifier.Verify"
+
+	tok, err := jwt.ParseSigned(token)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrUnauthenticated)
+	}
+
+	if len(tok.Headers) != 1 {
+		return nil, errors.Mark(errors.Newf("%s: expected one signature", op), ErrUnauthenticated)
+	}
+
+	keys := v.jwks.Key(tok.Headers[0].KeyID)
+	if len(keys) == 0 {
+		return nil, errors.Mark(errors.Newf("%s: unknown key %q", op, tok.Headers[0].KeyID), ErrUnauthenticated)
+	}
+
+	var c claims
+	for _, k := range keys {
+		if err = tok.Claims(k.Public(), &c); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrUnauthenticated)
+	}
+
+	now := v.now()
+
+	// Validate checks exp only when present
+	if c.Expiry == nil {
+		return nil, errors.Mark(errors.Newf("%s: token has no expiry", op), ErrUnauthenticated)
+	}
+	if c.Expiry.Time().Sub(now) > MaxTokenLifetime {
+		return nil, errors.Mark(errors.Newf("%s: token expires later than %s from now", op, MaxTokenLifetime), ErrUnauthenticated)
+	}
+
+	expected := jwt.Expected{Issuer: v.issuer, Time: now}
+	if v.audience != "" {
+		expected.Audience = jwt.Audience{v.audience}
+	}
+
+	if err := c.ValidateWithLeeway(expected, time.Minute); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrUnauthenticated)
+	}
+
+	return &Principal{
+		Subject:     c.Subject,
+		Tenant:      c.Tenant,
+		Roles:       c.Roles,
+		Permissions: strings.Fields(c.Scope),
+	}, nil
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+)
+
+var (
+	ErrUnauthenticated  = errors.New("Unauthenticated")
+	ErrPermissionDenied = errors.New("Permission denied")
+	ErrNoCredentials    = errors.New("No credentials")
+)
+
+// Principal is the authenticated caller
+type Principal struct {
+	Subject string
+	// Tenant the principal belongs to, empty for principals not bound to a tenant
+	Tenant      string
+	Roles       []string
+	Permissions []string
+}
+
+type principalKey struct{}
+
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// HasRole reports whether the principal has been granted role directly
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+)
+
+// NewRBAC returns an authorizer granting permissions to principals directly or through roles
+func NewRBAC(rolePermissions map[string][]string) *RBAC {
+	return &RBAC{roles: rolePermissions}
+}
+
+type RBAC struct {
+	roles map[string][]string
+}
+
+func (a *RBAC) Authorize(ctx context.Context, perm string) error {
+	p, ok := FromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+
+	if contains(p.Permissions, perm) {
+		return nil
+	}
+
+	for _, r := range p.Roles {
+		if contains(a.roles[r], perm) {
+			return nil
+		}
+	}
+
+	return errors.Wrapf(ErrPermissionDenied, "%s requires %s", p.Subject, perm)
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,524 @@
+// Package client is a typed Go client of the CustomerRegistry gRPC API. It returns domain
+// types and registry error marks, so callers can treat it as a registry.Service.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/pb"
+	"github.com/nacobas/customer/registry"
+	"github.com/nacobas/customer/transport"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultTimeout bounds a call, including retries, when the context has no deadline
+	DefaultTimeout = 10 * time.Second
+	// DefaultAttempts is the number of attempts of an idempotent call
+	DefaultAttempts = 3
+	// DefaultBackoff is the delay before the first retry, it doubles on every retry
+	DefaultBackoff = 100 * time.Millisecond
+)
+
+var _ registry.Service = (*Client)(nil)
+
+func NewClient(c pb.CustomerRegistryClient, opts ...Option) *Client {
+	cl := &Client{
+		c:        c,
+		timeout:  DefaultTimeout,
+		attempts: DefaultAttempts,
+		backoff:  DefaultBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(cl)
+	}
+
+	return cl
+}
+
+type Option func(*Client)
+
+// WithTimeout sets the deadline of calls whose context has none, zero disables it
+func WithTimeout(d time.Duration) Option {
+	return func(cl *Client) {
+		cl.timeout = d
+	}
+}
+
+// WithRetry sets the attempts and initial backoff of idempotent calls, one attempt
+// disables retries
+func WithRetry(attempts int, backoff time.Duration) Option {
+	return func(cl *Client) {
+		if attempts < 1 {
+			attempts = 1
+		}
+		cl.attempts, cl.backoff = attempts, backoff
+	}
+}
+
+// WithLanguage asks for validation messages in language lang, an Accept-Language value such
+// as fi or sv;q=0.9, en
+func WithLanguage(lang string) Option {
+	return func(cl *Client) {
+		cl.language = lang
+	}
+}
+
+type Client struct {
+	c        pb.CustomerRegistryClient
+	timeout  time.Duration
+	attempts int
+	backoff  time.Duration
+	language string
+}
+
+// FieldErrors returns the validation failures of fields described by the server, Tag and
+// Param are not carried over the wire
+func FieldErrors(err error) []customer.FieldError {
+	var se interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &se) {
+		return nil
+	}
+
+	var fes []customer.FieldError
+	for _, d := range se.GRPCStatus().Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, fv := range br.GetFieldViolations() {
+				fes = append(fes, customer.FieldError{Field: fv.GetField(), Message: fv.GetDescription()})
+			}
+		}
+	}
+	return fes
+}
+
+func (cl *Client) Get(ctx context.Context, id uint32) (*customer.Customer, error) {
+	const op string = "client.Client.Get"
+
+	var resp *pb.GetResponse
+
+	err := cl.call(ctx, true, func(ctx context.Context) (err error) {
+		resp, err = cl.c.Get(ctx, &pb.GetRequest{CustomerId: id})
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return fromPBCustomer(op, resp.GetCustomer())
+}
+
+// New sends the idempotency key of ctx, so that the registry returns the original customer
+// when a request is repeated after a lost response. New is retried only when ctx carries a
+// key, the caller vouching that the registry has idempotency enabled.
+func (cl *Client) New(ctx context.Context, i customer.Info) (*customer.Customer, error) {
+	const op string = "client.Client.New"
+
+	req := &pb.NewRequest{IdempotencyKey: registry.IdempotencyKeyFromContext(ctx)}
+	retry := req.IdempotencyKey != ""
+
+	switch i := i.(type) {
+	case *customer.PersonInfo:
+		req.CustomerInfo = &pb.NewRequest_PersonInfo{PersonInfo: transport.ToPBPersonInfo(i)}
+	case *customer.OrganizationInfo:
+		req.CustomerInfo = &pb.NewRequest_OrganizationInfo{OrganizationInfo: transport.ToPBOrganizationInfo(i)}
+	case *customer.SoleTraderInfo:
+		req.CustomerInfo = &pb.NewRequest_SoleTraderInfo{SoleTraderInfo: transport.ToPBSoleTraderInfo(i)}
+	default:
+		return nil, errors.Mark(errors.Wrap(transport.ErrMissingInfo, op), registry.ErrValidation)
+	}
+
+	var resp *pb.NewResponse
+
+	err := cl.call(ctx, retry, func(ctx context.Context) (err error) {
+		resp, err = cl.c.New(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return fromPBCustomer(op, resp.GetCustomer())
+}
+
+func (cl *Client) UpdateInfo(ctx context.Context, id uint32, i customer.Info) (*customer.Customer, error) {
+	return cl.updateInfo(ctx, "client.Client.UpdateInfo", &pb.UpdateInfoRequest{CustomerId: id}, i)
+}
+
+func (cl *Client) PatchInfo(ctx context.Context, id uint32, i customer.Info, fields []string) (*customer.Customer, error) {
+	// an empty mask replaces the whole info on the wire
+	if len(fields) == 0 {
+		return nil, errors.Mark(errors.New("client.Client.PatchInfo: no fields"), registry.ErrValidation)
+	}
+
+	req := &pb.UpdateInfoRequest{CustomerId: id, UpdateMask: transport.ToPBUpdateMask(fields)}
+
+	return cl.updateInfo(ctx, "client.Client.PatchInfo", req, i)
+}
+
+func (cl *Client) updateInfo(ctx context.Context, op string, req *pb.UpdateInfoRequest, i customer.Info) (*customer.Customer, error) {
+
+	switch i := i.(type) {
+	case *customer.PersonInfo:
+		req.CustomerInfo = &pb.UpdateInfoRequest_PersonInfo{PersonInfo: transport.ToPBPersonInfo(i)}
+	case *customer.OrganizationInfo:
+		req.CustomerInfo = &pb.UpdateInfoRequest_OrganizationInfo{OrganizationInfo: transport.ToPBOrganizationInfo(i)}
+	case *customer.SoleTraderInfo:
+		req.CustomerInfo = &pb.UpdateInfoRequest_SoleTraderInfo{SoleTraderInfo: transport.ToPBSoleTraderInfo(i)}
+	default:
+		return nil, errors.Mark(errors.Wrap(transport.ErrMissingInfo, op), registry.ErrValidation)
+	}
+
+	var resp *pb.UpdateInfoResponse
+
+	err := cl.call(ctx, false, func(ctx context.Context) (err error) {
+		resp, err = cl.c.UpdateInfo(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return fromPBCustomer(op, resp.GetCustomer())
+}
+
+func (cl *Client) SetState(ctx context.Context, id uint32, s customer.State) error {
+	const op string = "client.Client.SetState"
+
+	err := cl.call(ctx, false, func(ctx context.Context) error {
+		_, err := cl.c.SetState(ctx, &pb.SetStateRequest{CustomerId: id, State: pb.State(s - 1)})
+		return err
+	})
+
+	return errors.Wrap(err, op)
+}
+
+func (cl *Client) Erase(ctx context.Context, id uint32) error {
+	const op string = "client.Client.Erase"
+
+	err := cl.call(ctx, false, func(ctx context.Context) error {
+		_, err := cl.c.Erase(ctx, &pb.EraseRequest{CustomerId: id})
+		return err
+	})
+
+	return errors.Wrap(err, op)
+}
+
+func (cl *Client) SubjectAccessReport(ctx context.Context, ssn string) (*registry.SubjectAccessReport, error) {
+	const op string = "client.Client.SubjectAccessReport"
+
+	var resp *pb.SubjectAccessReportResponse
+
+	err := cl.call(ctx, false, func(ctx context.Context) (err error) {
+		resp, err = cl.c.SubjectAccessReport(ctx, &pb.SubjectAccessReportRequest{Ssn: ssn})
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	r, err := decodeReport(resp.GetJson())
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), registry.ErrUnexpected)
+	}
+
+	return r, nil
+}
+
+func (cl *Client) List(ctx context.Context, after uint32, limit int) ([]*customer.Customer, error) {
+	const op string = "client.Client.List"
+
+	var resp *pb.ListResponse
+
+	err := cl.call(ctx, true, func(ctx context.Context) (err error) {
+		resp, err = cl.c.List(ctx, &pb.ListRequest{PageToken: after, PageSize: uint32(limit)})
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return fromPBCustomers(op, resp.GetCustomers())
+}
+
+func (cl *Client) Search(ctx context.Context, query string, limit int) ([]*customer.Customer, error) {
+	const op string = "client.Client.Search"
+
+	var resp *pb.SearchResponse
+
+	err := cl.call(ctx, true, func(ctx context.Context) (err error) {
+		resp, err = cl.c.Search(ctx, &pb.SearchRequest{Query: query, Limit: uint32(limit)})
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return fromPBCustomers(op, resp.GetCustomers())
+}
+
+func (cl *Client) BatchGet(ctx context.Context, ids []uint32) ([]registry.BatchResult, error) {
+	const op string = "client.Client.BatchGet"
+
+	var resp *pb.BatchGetResponse
+
+	err := cl.call(ctx, true, func(ctx context.Context) (err error) {
+		resp, err = cl.c.BatchGet(ctx, &pb.BatchGetRequest{CustomerIds: ids})
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return fromPBBatchResults(op, resp.GetResults())
+}
+
+func (cl *Client) BatchSetState(ctx context.Context, updates []registry.StateUpdate) ([]registry.BatchResult, error) {
+	const op string = "client.Client.BatchSetState"
+
+	req := &pb.BatchSetStateRequest{}
+	for _, u := range updates {
+		req.Updates = append(req.Updates, &pb.SetStateRequest{CustomerId: u.ID, State: pb.State(u.State - 1)})
+	}
+
+	var resp *pb.BatchSetStateResponse
+
+	err := cl.call(ctx, false, func(ctx context.Context) (err error) {
+		resp, err = cl.c.BatchSetState(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return fromPBBatchResults(op, resp.GetResults())
+}
+
+func (cl *Client) GetAsOf(ctx context.Context, id uint32, validTime, recordedTime time.Time) (*registry.InfoVersion, error) {
+	const op string = "client.Client.GetAsOf"
+
+	req := &pb.GetAsOfRequest{
+		CustomerId:   id,
+		ValidTime:    transport.ToPBTime(validTime),
+		RecordedTime: transport.ToPBTime(recordedTime),
+	}
+
+	var resp *pb.GetAsOfResponse
+
+	err := cl.call(ctx, true, func(ctx context.Context) (err error) {
+		resp, err = cl.c.GetAsOf(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	v, err := transport.FromPBInfoVersion(resp.GetVersion())
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), registry.ErrUnexpected)
+	}
+
+	return v, nil
+}
+
+func (cl *Client) CorrectInfo(ctx context.Context, id uint32, i customer.Info, validFrom, validTo time.Time) (*customer.Customer, error) {
+	const op string = "client.Client.CorrectInfo"
+
+	req := &pb.CorrectInfoRequest{
+		CustomerId: id,
+		ValidFrom:  transport.ToPBTime(validFrom),
+		ValidTo:    transport.ToPBTime(validTo),
+	}
+
+	switch i := i.(type) {
+	case *customer.PersonInfo:
+		req.CustomerInfo = &pb.CorrectInfoRequest_PersonInfo{PersonInfo: transport.ToPBPersonInfo(i)}
+	case *customer.OrganizationInfo:
+		req.CustomerInfo = &pb.CorrectInfoRequest_OrganizationInfo{OrganizationInfo: transport.ToPBOrganizationInfo(i)}
+	case *customer.SoleTraderInfo:
+		req.CustomerInfo = &pb.CorrectInfoRequest_SoleTraderInfo{SoleTraderInfo: transport.ToPBSoleTraderInfo(i)}
+	default:
+		return nil, errors.Mark(errors.Wrap(transport.ErrMissingInfo, op), registry.ErrValidation)
+	}
+
+	var resp *pb.CorrectInfoResponse
+
+	err := cl.call(ctx, false, func(ctx context.Context) (err error) {
+		resp, err = cl.c.CorrectInfo(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return fromPBCustomer(op, resp.GetCustomer())
+}
+
+func (cl *Client) ConvertType(ctx context.Context, id uint32, i customer.Info) (*customer.Customer, error) {
+	const op string = "client.Client.ConvertType"
+
+	req := &pb.ConvertTypeRequest{CustomerId: id}
+
+	switch i := i.(type) {
+	case *customer.PersonInfo:
+		req.CustomerInfo = &pb.ConvertTypeRequest_PersonInfo{PersonInfo: transport.ToPBPersonInfo(i)}
+	case *customer.OrganizationInfo:
+		req.CustomerInfo = &pb.ConvertTypeRequest_OrganizationInfo{OrganizationInfo: transport.ToPBOrganizationInfo(i)}
+	case *customer.SoleTraderInfo:
+		req.CustomerInfo = &pb.ConvertTypeRequest_SoleTraderInfo{SoleTraderInfo: transport.ToPBSoleTraderInfo(i)}
+	default:
+		return nil, errors.Mark(errors.Wrap(transport.ErrMissingInfo, op), registry.ErrValidation)
+	}
+
+	var resp *pb.ConvertTypeResponse
+
+	err := cl.call(ctx, false, func(ctx context.Context) (err error) {
+		resp, err = cl.c.ConvertType(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return fromPBCustomer(op, resp.GetCustomer())
+}
+
+func (cl *Client) Merge(ctx context.Context, sourceID, targetID uint32) (*customer.Customer, error) {
+	const op string = "client.Client.Merge"
+
+	var resp *pb.MergeResponse
+
+	err := cl.call(ctx, false, func(ctx context.Context) (err error) {
+		resp, err = cl.c.Merge(ctx, &pb.MergeRequest{SourceId: sourceID, TargetId: targetID})
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return fromPBCustomer(op, resp.GetCustomer())
+}
+
+func (cl *Client) Unmerge(ctx context.Context, id uint32) (*customer.Customer, error) {
+	const op string = "client.Client.Unmerge"
+
+	var resp *pb.UnmergeResponse
+
+	err := cl.call(ctx, false, func(ctx context.Context) (err error) {
+		resp, err = cl.c.Unmerge(ctx, &pb.UnmergeRequest{CustomerId: id})
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return fromPBCustomer(op, resp.GetCustomer())
+}
+
+func (cl *Client) FindDuplicates(ctx context.Context, minScore float64, limit int) ([]registry.Duplicate, error) {
+	const op string = "client.Client.FindDuplicates"
+
+	var resp *pb.FindDuplicatesResponse
+
+	err := cl.call(ctx, true, func(ctx context.Context) (err error) {
+		resp, err = cl.c.FindDuplicates(ctx, &pb.FindDuplicatesRequest{MinScore: minScore, Limit: uint32(limit)})
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	ds := make([]registry.Duplicate, 0, len(resp.GetDuplicates()))
+	for _, d := range resp.GetDuplicates() {
+		ds = append(ds, transport.FromPBDuplicate(d))
+	}
+
+	return ds, nil
+}
+
+func fromPBCustomer(op string, pc *pb.Customer) (*customer.Customer, error) {
+	c, err := transport.FromPBCustomer(pc)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), registry.ErrUnexpected)
+	}
+	return c, nil
+}
+
+func fromPBCustomers(op string, pcs []*pb.Customer) ([]*customer.Customer, error) {
+	cs := make([]*customer.Customer, 0, len(pcs))
+	for _, pc := range pcs {
+		c, err := fromPBCustomer(op, pc)
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, c)
+	}
+	return cs, nil
+}
+
+func fromPBBatchResults(op string, prs []*pb.BatchResult) ([]registry.BatchResult, error) {
+	rs := make([]registry.BatchResult, 0, len(prs))
+	for _, pr := range prs {
+		r := registry.BatchResult{ID: pr.GetCustomerId()}
+
+		if err := status.ErrorProto(pr.GetStatus()); err != nil {
+			r.Err = errors.Wrap(markStatus(err), op)
+		} else {
+			c, err := fromPBCustomer(op, pr.GetCustomer())
+			if err != nil {
+				return nil, err
+			}
+			r.Customer = c
+		}
+
+		rs = append(rs, r)
+	}
+	return rs, nil
+}
+
+// decodeReport decodes the JSON report, the info of each record is decoded by its type
+func decodeReport(b []byte) (*registry.SubjectAccessReport, error) {
+	var doc struct {
+		registry.SubjectAccessReport
+		Customers []struct {
+			registry.SubjectRecord
+			Info json.RawMessage `json:"info"`
+		} `json:"customers"`
+	}
+
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	r := doc.SubjectAccessReport
+	r.Customers = nil
+
+	for _, rec := range doc.Customers {
+		var i customer.Info
+
+		switch rec.Type {
+		case customer.Private.String():
+			i = &customer.PersonInfo{}
+		case customer.Organization.String():
+			i = &customer.OrganizationInfo{}
+		case customer.SoleTrader.String():
+			i = &customer.SoleTraderInfo{}
+		default:
+			return nil, errors.Newf("unknown customer type %q", rec.Type)
+		}
+
+		if err := json.Unmarshal(rec.Info, i); err != nil {
+			return nil, err
+		}
+
+		rec.SubjectRecord.Info = i
+		r.Customers = append(r.Customers, rec.SubjectRecord)
+	}
+
+	return &r, nil
+}
@@ -0,0 +1,298 @@
+package client_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/client"
+	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/pb"
+	"github.com/nacobas/customer/registry"
+	"github.com/nacobas/customer/repo/inmem"
+	"github.com/nacobas/customer/transport"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rc := pb.NewCustomerRegistryClient(dial(t, registry.NewService(inmem.NewRepo())))
+	cl := client.NewClient(rc)
+
+	person := &customer.PersonInfo{
+		GivenName:        "given-name",
+		MiddleNames:      []string{"Anne Marie"},
+		FamilyNamePrefix: "van der",
+		FamilyName:       "family-name",
+		SSN:              "010170-123A",
+		DateOfBirth:      parseDate(t, "1970-01-01"),
+		Citizenship:      "FI"}
+
+	c, err := cl.New(ctx, person)
+	assert.Nil(t, err, "New should succeed")
+	assert.Equal(t, person, c.Info, "New should return domain info")
+	assert.Equal(t, customer.Prospect, c.State, "new customer is a prospect")
+
+	got, err := cl.Get(ctx, c.ID)
+	assert.Nil(t, err, "Get should succeed")
+	assert.Equal(t, c, got, "Get should return the created customer")
+
+	_, err = cl.Get(ctx, 99)
+	assert.True(t, errors.Is(err, registry.ErrNotFound), "NotFound should map back to ErrNotFound")
+
+	_, err = cl.New(ctx, &customer.PersonInfo{GivenName: "given-name"})
+	assert.True(t, errors.Is(err, registry.ErrValidation), "InvalidArgument should map back to ErrValidation")
+
+	fes := client.FieldErrors(err)
+	assert.Contains(t, fes, customer.FieldError{Field: "ssn", Message: "is required"}, "field errors should be carried")
+
+	_, err = client.NewClient(rc, client.WithLanguage("fi")).New(ctx, &customer.PersonInfo{GivenName: "given-name"})
+	assert.Contains(t, client.FieldErrors(err), customer.FieldError{Field: "ssn", Message: "on pakollinen"}, "field errors should be localised")
+
+	_, err = cl.New(ctx, person)
+	assert.True(t, errors.Is(err, registry.ErrConflict), "AlreadyExists should map back to ErrConflict")
+
+	assert.Nil(t, cl.SetState(ctx, c.ID, customer.Active), "SetState should succeed")
+
+	rs, err := cl.BatchSetState(ctx, []registry.StateUpdate{{ID: c.ID, State: customer.Passive}, {ID: 99, State: customer.Passive}})
+	assert.Nil(t, err, "BatchSetState should succeed")
+	assert.Nil(t, rs[0].Err, "existing customer")
+	assert.True(t, errors.Is(rs[1].Err, registry.ErrNotFound), "item status should map back to ErrNotFound")
+
+	rs, err = cl.BatchGet(ctx, []uint32{c.ID})
+	assert.Nil(t, err, "BatchGet should succeed")
+	assert.Equal(t, customer.Passive, rs[0].Customer.State, "batch state should be set")
+
+	assert.Nil(t, cl.SetState(ctx, c.ID, customer.Active), "SetState should succeed")
+
+	cs, err := cl.List(ctx, 0, 10)
+	assert.Nil(t, err, "List should succeed")
+	assert.Len(t, cs, 1, "one customer")
+	assert.Equal(t, customer.Active, cs[0].State, "state should be updated")
+
+	cs, err = cl.Search(ctx, "family", 0)
+	assert.Nil(t, err, "Search should succeed")
+	assert.Len(t, cs, 1, "search should match name")
+
+	r, err := cl.SubjectAccessReport(ctx, "010170-123A")
+	assert.Nil(t, err, "SubjectAccessReport should succeed")
+	assert.Len(t, r.Customers, 1, "one record")
+	assert.Equal(t, person, r.Customers[0].Info, "report info should be decoded by type")
+
+	trader := &customer.SoleTraderInfo{
+		GivenName:   "given-name",
+		FamilyName:  "family-name",
+		SSN:         "010170-123A",
+		DateOfBirth: parseDate(t, "1970-01-01"),
+		Citizenship: "FI",
+		TradeName:   "trade-name",
+		BusinessID:  "1234567-8"}
+
+	st, err := cl.New(ctx, trader)
+	assert.Nil(t, err, "New sole trader should succeed")
+	assert.Equal(t, trader, st.Info, "sole trader info should round trip")
+
+	r, err = cl.SubjectAccessReport(ctx, "010170-123A")
+	assert.Nil(t, err, "SubjectAccessReport should succeed")
+	assert.Len(t, r.Customers, 2, "private customer and sole trader")
+	for _, rec := range r.Customers {
+		if rec.ID == st.ID {
+			assert.Equal(t, trader, rec.Info, "sole trader info should be decoded by type")
+		}
+	}
+
+	org := &customer.OrganizationInfo{
+		Name:                "trade-name",
+		Form:                "Oy",
+		LeagalID:            "7654321-0",
+		RegistrationDate:    parseDate(t, "2000-01-01"),
+		RegistrationCountry: "FI"}
+
+	converted, err := cl.ConvertType(ctx, st.ID, org)
+	assert.Nil(t, err, "ConvertType should succeed")
+	assert.Equal(t, st.ID, converted.ID, "ConvertType should keep the ID")
+	assert.Equal(t, org, converted.Info, "organization info should round trip")
+
+	_, err = cl.ConvertType(ctx, c.ID, org)
+	assert.True(t, errors.Is(err, registry.ErrExpected), "FailedPrecondition should map back to ErrExpected")
+}
+
+func TestClientRetry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	testCases := []struct {
+		desc     string
+		failures int
+		code     codes.Code
+		call     func(cl *client.Client) error
+		calls    int
+		mark     error
+		// keys are the idempotency keys New was sent with
+		keys map[string]bool
+	}{
+		{
+			desc:     "idempotent call is retried",
+			failures: 2,
+			call:     func(cl *client.Client) error { _, err := cl.Get(ctx, 1); return err },
+			calls:    3,
+		},
+		{
+			desc:     "retries are bounded",
+			failures: 5,
+			call:     func(cl *client.Client) error { _, err := cl.Get(ctx, 1); return err },
+			calls:    3,
+			mark:     registry.ErrUnexpected,
+		},
+		{
+			desc:     "New with an idempotency key is retried with the key",
+			failures: 2,
+			call: func(cl *client.Client) error {
+				_, err := cl.New(registry.NewIdempotencyContext(ctx, "key-1"), &customer.OrganizationInfo{})
+				return err
+			},
+			calls: 3,
+			keys:  map[string]bool{"key-1": true},
+		},
+		{
+			desc:     "New without an idempotency key is not retried",
+			failures: 1,
+			call: func(cl *client.Client) error {
+				_, err := cl.New(ctx, &customer.OrganizationInfo{})
+				return err
+			},
+			calls: 1,
+			mark:  registry.ErrUnexpected,
+			keys:  map[string]bool{"": true},
+		},
+		{
+			desc:     "changes are not retried",
+			failures: 1,
+			call: func(cl *client.Client) error {
+				_, err := cl.CorrectInfo(ctx, 1, &customer.OrganizationInfo{}, time.Now().Add(-time.Hour), time.Time{})
+				return err
+			},
+			calls: 1,
+			mark:  registry.ErrUnexpected,
+		},
+		{
+			desc:     "validation errors are not retried",
+			failures: 1,
+			code:     codes.InvalidArgument,
+			call:     func(cl *client.Client) error { _, err := cl.Get(ctx, 1); return err },
+			calls:    1,
+			mark:     registry.ErrValidation,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			f := &flaky{failures: tC.failures, code: tC.code}
+			cl := client.NewClient(f, client.WithRetry(3, time.Millisecond))
+
+			err := tC.call(cl)
+			assert.Equal(t, tC.calls, f.calls, "calls")
+			if tC.keys != nil {
+				assert.Equal(t, tC.keys, f.keys, "idempotency keys")
+			}
+			if tC.mark == nil {
+				assert.Nil(t, err, "error should be nil")
+			} else {
+				assert.True(t, errors.Is(err, tC.mark), "error should be marked")
+			}
+		})
+	}
+}
+
+func TestClientDeadline(t *testing.T) {
+	t.Parallel()
+
+	f := &flaky{}
+	cl := client.NewClient(f, client.WithTimeout(time.Minute))
+
+	_, err := cl.Get(context.Background(), 1)
+	assert.Nil(t, err, "error should be nil")
+	assert.True(t, f.deadline, "default deadline should be set")
+}
+
+// flaky fails the first calls with code, unavailable by default
+type flaky struct {
+	pb.CustomerRegistryClient
+	failures int
+	code     codes.Code
+	calls    int
+	deadline bool
+	keys     map[string]bool
+}
+
+func (f *flaky) fail(ctx context.Context) error {
+	f.calls++
+	_, f.deadline = ctx.Deadline()
+	if f.calls <= f.failures {
+		if f.code == codes.OK {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		return status.Error(f.code, f.code.String())
+	}
+	return nil
+}
+
+func (f *flaky) Get(ctx context.Context, req *pb.GetRequest, opts ...grpc.CallOption) (*pb.GetResponse, error) {
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	return &pb.GetResponse{Customer: &pb.Customer{Id: 1, Info: &pb.Customer_OrganizationInfo{OrganizationInfo: &pb.OrganizationInfo{}}}}, nil
+}
+
+func (f *flaky) New(ctx context.Context, req *pb.NewRequest, opts ...grpc.CallOption) (*pb.NewResponse, error) {
+	if f.keys == nil {
+		f.keys = map[string]bool{}
+	}
+	f.keys[req.GetIdempotencyKey()] = true
+
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	return &pb.NewResponse{Customer: &pb.Customer{Id: 1, Info: &pb.Customer_OrganizationInfo{OrganizationInfo: &pb.OrganizationInfo{}}}}, nil
+}
+
+func (f *flaky) CorrectInfo(ctx context.Context, req *pb.CorrectInfoRequest, opts ...grpc.CallOption) (*pb.CorrectInfoResponse, error) {
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	return &pb.CorrectInfoResponse{Customer: &pb.Customer{Id: 1, Info: &pb.Customer_OrganizationInfo{OrganizationInfo: &pb.OrganizationInfo{}}}}, nil
+}
+
+func dial(t *testing.T, svc registry.Service) *grpc.ClientConn {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	pb.RegisterCustomerRegistryServer(srv, transport.NewGRPCServer(svc))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithInsecure(), grpc.WithContextDialer(
+		func(context.Context, string) (net.Conn, error) { return lis.Dial() }))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func parseDate(t *testing.T, datestr string) date.Date {
+	d, err := date.ParseDate(datestr)
+	if err != nil {
+		t.Fatalf("Failed to parse date from: %s, error: %v", datestr, err)
+	}
+	return d
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/auth"
+	"github.com/nacobas/customer/registry"
+	"github.com/nacobas/customer/transport"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// retryable codes are transient, the request may not have reached the registry
+var retryable = map[codes.Code]bool{
+	codes.Unavailable:       true,
+	codes.ResourceExhausted: true,
+	codes.Aborted:           true,
+}
+
+// call runs rpc under the default deadline, retrying transient failures of idempotent calls
+// with exponential backoff and jitter, and maps the final status to a registry error mark.
+// Calls that change customers are not idempotent: a retry after a lost response would repeat
+// a correction or audit entry, or fail on the changed customer.
+func (cl *Client) call(ctx context.Context, idempotent bool, rpc func(context.Context) error) error {
+	if _, ok := ctx.Deadline(); !ok && cl.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cl.timeout)
+		defer cancel()
+	}
+
+	if cl.language != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, transport.AcceptLanguageHeader, cl.language)
+	}
+
+	attempts := 1
+	if idempotent {
+		attempts = cl.attempts
+	}
+
+	backoff := cl.backoff
+
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		if err = rpc(ctx); err == nil {
+			return nil
+		}
+
+		if attempt >= attempts || !retryable[status.Code(err)] {
+			break
+		}
+
+		// full jitter
+		wait := time.Duration(rand.Int63n(int64(backoff) + 1))
+		backoff *= 2
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return markStatus(err)
+		}
+	}
+
+	return markStatus(err)
+}
+
+// markStatus reverses the mapping of registry error marks to gRPC status codes done by the
+// transport package
+func markStatus(err error) error {
+	var mark error
+
+	switch status.Code(err) {
+	case codes.Unauthenticated:
+		mark = auth.ErrUnauthenticated
+	case codes.PermissionDenied:
+		mark = registry.ErrPermission
+	case codes.AlreadyExists:
+		mark = registry.ErrConflict
+	case codes.InvalidArgument:
+		mark = registry.ErrValidation
+	case codes.NotFound:
+		mark = registry.ErrNotFound
+	case codes.FailedPrecondition:
+		mark = registry.ErrExpected
+	default:
+		mark = registry.ErrUnexpected
+	}
+
+	return errors.Mark(err, mark)
+}
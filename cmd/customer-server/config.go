@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/registry"
+	"github.com/nacobas/customer/tenant"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrInvalidConfig = errors.New("Invalid configuration")
+)
+
+// Config is loaded from defaults, then a YAML file, then CUSTOMER_* environment variables
+// and finally command line flags, later sources override earlier ones.
+type Config struct {
+	ListenAddress string `yaml:"listen_address"`
+	// HTTPListenAddress enables the HTTP/JSON API
+	HTTPListenAddress string        `yaml:"http_listen_address"`
+	LogLevel          string        `yaml:"log_level"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`
+	Repo              RepoConfig    `yaml:"repo"`
+	TLS               TLSConfig     `yaml:"tls"`
+	Auth              AuthConfig    `yaml:"auth"`
+	RedactionPolicy   string        `yaml:"redaction_policy"`
+	// Audit records changes without info values, so the audit log is not purged on Erase
+	Audit       bool `yaml:"audit"`
+	RiskScoring bool `yaml:"risk_scoring"`
+	// InfoHistory keeps every version of customer info for as-of queries and corrections. It
+	// is the only store purged on Erase, search reads the repo and blind indexes follow the
+	// erased record.
+	InfoHistory bool `yaml:"info_history"`
+	// LegalHolds defer Erase of the listed customers, an erasure requested during a hold is
+	// recorded and must be repeated once the hold has expired
+	LegalHolds []LegalHoldConfig `yaml:"legal_holds"`
+	// MergeGracePeriod is how long a merge can be undone
+	MergeGracePeriod time.Duration `yaml:"merge_grace_period"`
+	// NameCasing is how names are cased when normalised: keep, title or upper
+	NameCasing string `yaml:"name_casing"`
+	// IdempotencyTTL is how long New idempotency keys are remembered, zero disables them
+	IdempotencyTTL time.Duration `yaml:"idempotency_ttl"`
+	// ValidationProfiles add validation rules for tenants and countries
+	ValidationProfiles []ProfileConfig `yaml:"validation_profiles"`
+	AgeRules           AgeRulesConfig  `yaml:"age_rules"`
+}
+
+// AgeRulesConfig bounds dates of birth and registration, zero disables a rule
+type AgeRulesConfig struct {
+	// MinActiveAge is the minimum age of persons and sole traders to become Active
+	MinActiveAge int `yaml:"min_active_age"`
+	// MaxAge is the highest age in years a date of birth may give
+	MaxAge               int       `yaml:"max_age"`
+	EarliestRegistration time.Time `yaml:"earliest_registration"`
+}
+
+func (ac AgeRulesConfig) rules() customer.AgeRules {
+	return customer.AgeRules{
+		MinActiveAge:         ac.MinActiveAge,
+		MaxAge:               ac.MaxAge,
+		EarliestRegistration: ac.EarliestRegistration,
+	}
+}
+
+type LegalHoldConfig struct {
+	Tenant     string    `yaml:"tenant"`
+	CustomerID uint32    `yaml:"customer_id"`
+	Until      time.Time `yaml:"until"`
+}
+
+func (cfg Config) legalHolds() *registry.LegalHolds {
+	lh := registry.NewLegalHolds()
+	for _, h := range cfg.LegalHolds {
+		lh.Place(tenant.NewContext(context.Background(), h.Tenant), h.CustomerID, h.Until)
+	}
+	return lh
+}
+
+type RepoConfig struct {
+	// Backend selects the repo implementation, only inmem is available
+	Backend string `yaml:"backend"`
+	// EncryptionKeys is a key file enabling field level encryption
+	EncryptionKeys string `yaml:"encryption_keys"`
+	// RotationInterval is how often the key file is re-read and values not under the current
+	// key re-encrypted, zero disables rotation
+	RotationInterval time.Duration `yaml:"rotation_interval"`
+}
+
+type TLSConfig struct {
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// ClientCAFile enables mutual TLS
+	ClientCAFile string `yaml:"client_ca_file"`
+}
+
+type AuthConfig struct {
+	JWKSFile string              `yaml:"jwks_file"`
+	Issuer   string              `yaml:"issuer"`
+	Audience string              `yaml:"audience"`
+	Roles    map[string][]string `yaml:"roles"`
+}
+
+// ProfileConfig is a validation profile and the tenants and countries it applies to
+type ProfileConfig struct {
+	Name      string   `yaml:"name"`
+	Version   uint32   `yaml:"version"`
+	Tenants   []string `yaml:"tenants"`
+	Countries []string `yaml:"countries"`
+	// Required names info fields by their JSON names, e.g. middle_names
+	Required              []string `yaml:"required"`
+	MinAge                int      `yaml:"min_age"`
+	Citizenships          []string `yaml:"citizenships"`
+	RegistrationCountries []string `yaml:"registration_countries"`
+}
+
+func (cfg Config) profiles() (registry.Profiles, error) {
+	bs := make([]registry.ProfileBinding, 0, len(cfg.ValidationProfiles))
+	for _, p := range cfg.ValidationProfiles {
+		bs = append(bs, registry.ProfileBinding{
+			Profile: customer.Profile{
+				Name:                  p.Name,
+				Version:               p.Version,
+				Required:              p.Required,
+				MinAge:                p.MinAge,
+				Citizenships:          p.Citizenships,
+				RegistrationCountries: p.RegistrationCountries,
+			},
+			Tenants:   p.Tenants,
+			Countries: p.Countries,
+		})
+	}
+	return registry.CompileProfiles(bs, cfg.AgeRules.rules())
+}
+
+func defaultConfig() Config {
+	return Config{
+		ListenAddress:    ":50051",
+		LogLevel:         "info",
+		ShutdownTimeout:  30 * time.Second,
+		IdempotencyTTL:   24 * time.Hour,
+		MergeGracePeriod: registry.DefaultMergeGracePeriod,
+		AgeRules: AgeRulesConfig{
+			MinActiveAge:         customer.DefaultAgeRules.MinActiveAge,
+			MaxAge:               customer.DefaultAgeRules.MaxAge,
+			EarliestRegistration: customer.DefaultAgeRules.EarliestRegistration,
+		},
+		Repo: RepoConfig{
+			Backend:          "inmem",
+			RotationInterval: time.Hour,
+		},
+	}
+}
+
+func loadConfig(args []string, getenv func(string) string) (Config, error) {
+	const op string = "main.loadConfig"
+
+	cfg := defaultConfig()
+
+	fs := flag.NewFlagSet("customer-server", flag.ContinueOnError)
+	path := fs.String("config", getenv("CUSTOMER_CONFIG"), "path to YAML config file")
+	listen := fs.String("listen", "", "listen address")
+	httpListen := fs.String("http-listen", "", "HTTP/JSON API listen address, disabled when empty")
+	logLevel := fs.String("log-level", "", "log level: debug, info, warn or error")
+	backend := fs.String("repo", "", "repo backend")
+	certFile := fs.String("tls-cert", "", "TLS certificate file")
+	keyFile := fs.String("tls-key", "", "TLS key file")
+	clientCA := fs.String("tls-client-ca", "", "client CA file, enables mutual TLS")
+
+	if err := fs.Parse(args); err != nil {
+		return cfg, errors.Mark(errors.Wrap(err, op), ErrInvalidConfig)
+	}
+
+	if *path != "" {
+		b, err := ioutil.ReadFile(*path)
+		if err != nil {
+			return cfg, errors.Wrap(err, op)
+		}
+		if err := yaml.Unmarshal(b, &cfg); err != nil {
+			return cfg, errors.Mark(errors.Wrapf(err, "%s: %s", op, *path), ErrInvalidConfig)
+		}
+	}
+
+	override(&cfg.ListenAddress, getenv("CUSTOMER_LISTEN_ADDRESS"), *listen)
+	override(&cfg.HTTPListenAddress, getenv("CUSTOMER_HTTP_LISTEN_ADDRESS"), *httpListen)
+	override(&cfg.LogLevel, getenv("CUSTOMER_LOG_LEVEL"), *logLevel)
+	override(&cfg.Repo.Backend, getenv("CUSTOMER_REPO_BACKEND"), *backend)
+	override(&cfg.TLS.CertFile, getenv("CUSTOMER_TLS_CERT_FILE"), *certFile)
+	override(&cfg.TLS.KeyFile, getenv("CUSTOMER_TLS_KEY_FILE"), *keyFile)
+	override(&cfg.TLS.ClientCAFile, getenv("CUSTOMER_TLS_CLIENT_CA_FILE"), *clientCA)
+
+	return cfg, cfg.validate()
+}
+
+func override(field *string, values ...string) {
+	for _, v := range values {
+		if v != "" {
+			*field = v
+		}
+	}
+}
+
+func (cfg Config) validate() error {
+	const op string = "main.Config.validate"
+
+	if cfg.ListenAddress == "" {
+		return errors.Mark(errors.Newf("%s: listen address missing", op), ErrInvalidConfig)
+	}
+
+	if _, ok := levels[strings.ToLower(cfg.LogLevel)]; !ok {
+		return errors.Mark(errors.Newf("%s: unknown log level %q", op, cfg.LogLevel), ErrInvalidConfig)
+	}
+
+	if cfg.Repo.Backend != "inmem" {
+		return errors.Mark(errors.Newf("%s: unknown repo backend %q", op, cfg.Repo.Backend), ErrInvalidConfig)
+	}
+
+	if cfg.Repo.RotationInterval < 0 {
+		return errors.Mark(errors.Newf("%s: negative key rotation interval", op), ErrInvalidConfig)
+	}
+
+	if _, err := customer.ParseCasing(cfg.NameCasing); err != nil {
+		return errors.Mark(errors.Wrap(err, op), ErrInvalidConfig)
+	}
+
+	if cfg.AgeRules.MinActiveAge < 0 || cfg.AgeRules.MaxAge < 0 {
+		return errors.Mark(errors.Newf("%s: negative age", op), ErrInvalidConfig)
+	}
+
+	for _, p := range cfg.ValidationProfiles {
+		if p.Name == "" {
+			return errors.Mark(errors.Newf("%s: validation profile name missing", op), ErrInvalidConfig)
+		}
+		if p.MinAge < 0 {
+			return errors.Mark(errors.Newf("%s: validation profile %s: negative minimum age", op, p.Name), ErrInvalidConfig)
+		}
+	}
+
+	for _, h := range cfg.LegalHolds {
+		if h.CustomerID == 0 || h.Until.IsZero() {
+			return errors.Mark(errors.Newf("%s: legal hold needs a customer ID and an end time", op), ErrInvalidConfig)
+		}
+	}
+
+	if _, err := cfg.profiles(); err != nil {
+		return errors.Mark(errors.Wrap(err, op), ErrInvalidConfig)
+	}
+
+	if (cfg.TLS.CertFile == "") != (cfg.TLS.KeyFile == "") {
+		return errors.Mark(errors.Newf("%s: TLS needs both certificate and key", op), ErrInvalidConfig)
+	}
+
+	if cfg.TLS.ClientCAFile != "" && cfg.TLS.CertFile == "" {
+		return errors.Mark(errors.Newf("%s: mutual TLS needs a server certificate", op), ErrInvalidConfig)
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/tenant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := ioutil.WriteFile(path, []byte(`
+listen_address: ":6000"
+log_level: warn
+shutdown_timeout: 5s
+audit: true
+`), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	negative := filepath.Join(t.TempDir(), "negative.yaml")
+	if err := ioutil.WriteFile(negative, []byte("repo:\n  rotation_interval: -1h\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	testCases := []struct {
+		desc   string
+		args   []string
+		env    map[string]string
+		listen string
+		level  string
+		err    error
+	}{
+		{
+			desc:   "defaults",
+			listen: ":50051",
+			level:  "info",
+		},
+		{
+			desc:   "file",
+			args:   []string{"-config", path},
+			listen: ":6000",
+			level:  "warn",
+		},
+		{
+			desc:   "env overrides file",
+			env:    map[string]string{"CUSTOMER_CONFIG": path, "CUSTOMER_LISTEN_ADDRESS": ":7000"},
+			listen: ":7000",
+			level:  "warn",
+		},
+		{
+			desc:   "flags override env",
+			args:   []string{"-listen", ":8000", "-log-level", "debug"},
+			env:    map[string]string{"CUSTOMER_CONFIG": path, "CUSTOMER_LISTEN_ADDRESS": ":7000"},
+			listen: ":8000",
+			level:  "debug",
+		},
+		{
+			desc: "unknown backend",
+			args: []string{"-repo", "postgres"},
+			err:  ErrInvalidConfig,
+		},
+		{
+			desc: "negative rotation interval",
+			args: []string{"-config", negative},
+			err:  ErrInvalidConfig,
+		},
+		{
+			desc: "TLS key missing",
+			args: []string{"-tls-cert", "server.pem"},
+			err:  ErrInvalidConfig,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			getenv := func(k string) string { return tC.env[k] }
+
+			got, err := loadConfig(tC.args, getenv)
+
+			if tC.err == nil {
+				assert.Nil(t, err, "error should be nil")
+				assert.Equal(t, tC.listen, got.ListenAddress, "listen address")
+				assert.Equal(t, tC.level, got.LogLevel, "log level")
+			} else {
+				assert.True(t, errors.Is(err, tC.err), "Expected error should be found in the chain")
+			}
+		})
+	}
+
+	cfg, err := loadConfig([]string{"-config", path}, func(string) string { return "" })
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, 5*time.Second, cfg.ShutdownTimeout, "durations should parse")
+	assert.True(t, cfg.Audit, "audit should be enabled")
+}
+
+func TestValidationProfilesConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc     string
+		profiles []ProfileConfig
+		err      error
+	}{
+		{
+			desc:     "valid",
+			profiles: []ProfileConfig{{Name: "fi", Version: 1, Countries: []string{"FI"}, Required: []string{"middle_names"}, MinAge: 18}},
+		},
+		{
+			desc:     "name missing",
+			profiles: []ProfileConfig{{Version: 1}},
+			err:      ErrInvalidConfig,
+		},
+		{
+			desc:     "unknown required field",
+			profiles: []ProfileConfig{{Name: "fi", Required: []string{"shoe_size"}}},
+			err:      ErrInvalidConfig,
+		},
+		{
+			desc:     "duplicate name",
+			profiles: []ProfileConfig{{Name: "fi"}, {Name: "fi"}},
+			err:      ErrInvalidConfig,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := defaultConfig()
+			cfg.ValidationProfiles = tC.profiles
+
+			err := cfg.validate()
+
+			if tC.err == nil {
+				assert.Nil(t, err, "error should be nil")
+			} else {
+				assert.True(t, errors.Is(err, tC.err), "Expected error should be found in the chain")
+			}
+		})
+	}
+}
+
+func TestLegalHoldsConfig(t *testing.T) {
+	t.Parallel()
+
+	until := time.Now().Add(time.Hour).UTC()
+
+	testCases := []struct {
+		desc  string
+		holds []LegalHoldConfig
+		err   error
+	}{
+		{
+			desc:  "valid",
+			holds: []LegalHoldConfig{{Tenant: "bank-fi", CustomerID: 1, Until: until}},
+		},
+		{
+			desc:  "customer ID missing",
+			holds: []LegalHoldConfig{{Tenant: "bank-fi", Until: until}},
+			err:   ErrInvalidConfig,
+		},
+		{
+			desc:  "end time missing",
+			holds: []LegalHoldConfig{{Tenant: "bank-fi", CustomerID: 1}},
+			err:   ErrInvalidConfig,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := defaultConfig()
+			cfg.LegalHolds = tC.holds
+
+			err := cfg.validate()
+
+			if tC.err == nil {
+				assert.Nil(t, err, "error should be nil")
+			} else {
+				assert.True(t, errors.Is(err, tC.err), "Expected error should be found in the chain")
+			}
+		})
+	}
+
+	cfg := defaultConfig()
+	cfg.LegalHolds = []LegalHoldConfig{{Tenant: "bank-fi", CustomerID: 1, Until: until}}
+	lh := cfg.legalHolds()
+
+	got, err := lh.Until(tenant.NewContext(context.Background(), "bank-fi"), &customer.Customer{ID: 1})
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, until, got, "customer should be held")
+
+	got, err = lh.Until(tenant.NewContext(context.Background(), "bank-se"), &customer.Customer{ID: 1})
+	assert.Nil(t, err, "error should be nil")
+	assert.True(t, got.IsZero(), "hold should only apply to its tenant")
+}
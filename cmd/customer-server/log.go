@@ -0,0 +1,59 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strings"
+)
+
+type level int
+
+const (
+	debugLevel level = iota
+	infoLevel
+	warnLevel
+	errorLevel
+)
+
+var levels = map[string]level{
+	"debug": debugLevel,
+	"info":  infoLevel,
+	"warn":  warnLevel,
+	"error": errorLevel,
+}
+
+// logger is a minimal leveled logger over the standard library log package
+type logger struct {
+	level level
+	l     *log.Logger
+}
+
+func newLogger(lvl string) *logger {
+	return &logger{
+		level: levels[strings.ToLower(lvl)],
+		l:     log.New(os.Stderr, "", log.LstdFlags|log.LUTC),
+	}
+}
+
+func (lg *logger) logf(lvl level, prefix, format string, args ...interface{}) {
+	if lvl < lg.level {
+		return
+	}
+	lg.l.Printf(prefix+format, args...)
+}
+
+func (lg *logger) Debugf(format string, args ...interface{}) {
+	lg.logf(debugLevel, "DEBUG ", format, args...)
+}
+
+func (lg *logger) Infof(format string, args ...interface{}) {
+	lg.logf(infoLevel, "INFO ", format, args...)
+}
+
+func (lg *logger) Warnf(format string, args ...interface{}) {
+	lg.logf(warnLevel, "WARN ", format, args...)
+}
+
+func (lg *logger) Errorf(format string, args ...interface{}) {
+	lg.logf(errorLevel, "ERROR ", format, args...)
+}
@@ -0,0 +1,324 @@
+// Command customer-server serves the CustomerRegistry gRPC API and optionally an HTTP/JSON API
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/auth"
+	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/dedup"
+	"github.com/nacobas/customer/pb"
+	"github.com/nacobas/customer/registry"
+	"github.com/nacobas/customer/repo/encrypted"
+	"github.com/nacobas/customer/repo/inmem"
+	"github.com/nacobas/customer/risk"
+	"github.com/nacobas/customer/transport"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	cfg, err := loadConfig(os.Args[1:], os.Getenv)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	log := newLogger(cfg.LogLevel)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, cfg, log); err != nil {
+		log.Errorf("%v", err)
+		os.Exit(1)
+	}
+}
+
+// run serves until ctx is done, then drains in-flight RPCs for at most cfg.ShutdownTimeout
+func run(ctx context.Context, cfg Config, log *logger) error {
+	const op string = "main.run"
+
+	svc, err := newService(ctx, cfg, log)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	srv, hsrv, err := newServers(cfg, svc, log)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	lis, err := net.Listen("tcp", cfg.ListenAddress)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+	hs.SetServingStatus(pb.CustomerRegistry_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	reflection.Register(srv)
+
+	errc := make(chan error, 2)
+	go func() {
+		log.Infof("listening on %s", lis.Addr())
+		errc <- srv.Serve(lis)
+	}()
+
+	if hsrv != nil {
+		hlis, err := net.Listen("tcp", cfg.HTTPListenAddress)
+		if err != nil {
+			srv.Stop()
+			return errors.Wrap(err, op)
+		}
+
+		go func() {
+			log.Infof("HTTP API listening on %s", hlis.Addr())
+			if hsrv.TLSConfig != nil {
+				errc <- hsrv.ServeTLS(hlis, "", "")
+				return
+			}
+			errc <- hsrv.Serve(hlis)
+		}()
+	}
+
+	select {
+	case err := <-errc:
+		return errors.Wrap(err, op)
+	case <-ctx.Done():
+	}
+
+	log.Infof("shutting down, draining in-flight requests")
+	hs.Shutdown()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		if hsrv != nil {
+			if err := hsrv.Shutdown(shutdownCtx); err != nil {
+				hsrv.Close()
+			}
+		}
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		log.Warnf("shutdown timeout %s exceeded, closing remaining connections", cfg.ShutdownTimeout)
+		srv.Stop()
+		if hsrv != nil {
+			hsrv.Close()
+		}
+	}
+
+	return nil
+}
+
+func newService(ctx context.Context, cfg Config, log *logger) (registry.Service, error) {
+	var (
+		repo registry.Repo = inmem.NewRepo()
+		kp   *encrypted.FileKeyProvider
+	)
+
+	if cfg.Repo.EncryptionKeys != "" {
+		var err error
+		kp, err = encrypted.NewFileKeyProvider(cfg.Repo.EncryptionKeys)
+		if err != nil {
+			return nil, err
+		}
+
+		er := encrypted.NewRepo(repo, kp, inmem.NewBlindIndex())
+		if cfg.Repo.RotationInterval > 0 {
+			go er.RunRotation(ctx, cfg.Repo.RotationInterval, func(err error) {
+				log.Errorf("key rotation: %v", err)
+			})
+		}
+		repo = er
+	}
+
+	casing, err := customer.ParseCasing(cfg.NameCasing)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles, err := cfg.profiles()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []registry.Option{
+		registry.WithNameCasing(casing),
+		registry.WithAgeRules(cfg.AgeRules.rules()),
+		registry.WithValidationProfiles(profiles),
+		registry.WithMergeGracePeriod(cfg.MergeGracePeriod),
+		registry.WithDuplicateFinder(dedup.NewEngine()),
+		registry.WithLegalHold(cfg.legalHolds()),
+	}
+
+	if cfg.Audit {
+		opts = append(opts, registry.WithAuditLog(inmem.NewAuditLog()))
+	}
+
+	if cfg.RiskScoring {
+		opts = append(opts, registry.WithRiskScorer(risk.NewEngine()))
+	}
+
+	if cfg.InfoHistory {
+		history := inmem.NewInfoHistory()
+		if kp != nil {
+			history = encrypted.NewInfoHistory(history, kp)
+		}
+		opts = append(opts, registry.WithInfoHistory(history))
+	}
+
+	if cfg.IdempotencyTTL > 0 {
+		opts = append(opts, registry.WithIdempotency(inmem.NewIdempotencyStore(cfg.IdempotencyTTL)))
+	}
+
+	if cfg.authEnabled() {
+		opts = append(opts, registry.WithAuthorizer(auth.NewRBAC(cfg.Auth.Roles)))
+	}
+
+	return registry.NewService(repo, opts...), nil
+}
+
+// newServers returns the gRPC server and, when configured, the HTTP/JSON API server sharing
+// its TLS, authentication and redaction settings
+func newServers(cfg Config, svc registry.Service, log *logger) (*grpc.Server, *http.Server, error) {
+	var (
+		tc             *tls.Config
+		authenticators []transport.Authenticator
+		srvOpts        = []transport.ServerOption{transport.WithErrorLog(log)}
+		grpcOpts       []grpc.ServerOption
+	)
+
+	if cfg.TLS.CertFile != "" {
+		var err error
+		if tc, err = tlsConfig(cfg); err != nil {
+			return nil, nil, err
+		}
+		grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(tc)))
+	}
+
+	if cfg.TLS.ClientCAFile != "" {
+		authenticators = append(authenticators, transport.MTLSAuthenticator())
+	}
+
+	if cfg.Auth.JWKSFile != "" {
+		v, err := auth.NewJWTVerifier(cfg.Auth.JWKSFile, cfg.Auth.Issuer, cfg.Auth.Audience)
+		if err != nil {
+			return nil, nil, err
+		}
+		authenticators = append(authenticators, transport.BearerAuthenticator(v))
+	}
+
+	if cfg.RedactionPolicy != "" {
+		p, err := transport.LoadRedactionPolicy(cfg.RedactionPolicy)
+		if err != nil {
+			return nil, nil, err
+		}
+		srvOpts = append(srvOpts, transport.WithRedaction(p))
+	}
+
+	grpcOpts = append(grpcOpts,
+		grpc.ChainUnaryInterceptor(
+			logInterceptor(log),
+			transport.UnaryAuthInterceptor(authenticators...),
+			transport.UnaryTenantInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			transport.StreamAuthInterceptor(authenticators...),
+			transport.StreamTenantInterceptor(),
+		),
+	)
+
+	srv := grpc.NewServer(grpcOpts...)
+	pb.RegisterCustomerRegistryServer(srv, transport.NewGRPCServer(svc, srvOpts...))
+
+	if cfg.HTTPListenAddress == "" {
+		return srv, nil, nil
+	}
+
+	hsrv := &http.Server{
+		Handler:           logHandler(log, transport.NewHTTPHandler(svc, authenticators, srvOpts...)),
+		TLSConfig:         tc,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return srv, hsrv, nil
+}
+
+func tlsConfig(cfg Config) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tc := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.TLS.ClientCAFile != "" {
+		pem, err := ioutil.ReadFile(cfg.TLS.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tc.ClientCAs = x509.NewCertPool()
+		if !tc.ClientCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.Newf("no certificates in %s", cfg.TLS.ClientCAFile)
+		}
+
+		// JWT callers may connect without a client certificate
+		tc.ClientAuth = tls.RequireAndVerifyClientCert
+		if cfg.Auth.JWKSFile != "" {
+			tc.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+	}
+
+	return tc, nil
+}
+
+func logInterceptor(log *logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		if err != nil {
+			log.Debugf("%s failed after %s: %v", info.FullMethod, time.Since(start), err)
+			return resp, err
+		}
+		log.Debugf("%s took %s", info.FullMethod, time.Since(start))
+		return resp, err
+	}
+}
+
+func logHandler(log *logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		next.ServeHTTP(w, r)
+		log.Debugf("%s %s took %s", r.Method, r.URL.Path, time.Since(start))
+	})
+}
+
+func (cfg Config) authEnabled() bool {
+	return cfg.Auth.JWKSFile != "" || cfg.TLS.ClientCAFile != ""
+}
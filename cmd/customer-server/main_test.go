@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/nacobas/customer/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to reserve port: %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+
+	cfg := defaultConfig()
+	cfg.ListenAddress = addr
+	cfg.ShutdownTimeout = time.Second
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx, cfg, &logger{level: errorLevel, l: log.New(ioutil.Discard, "", 0)})
+	}()
+
+	dialCtx, dialCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer dialCancel()
+	conn, err := grpc.DialContext(dialCtx, addr, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		t.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	hc, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{
+		Service: pb.CustomerRegistry_ServiceDesc.ServiceName,
+	})
+	assert.Nil(t, err, "health check should succeed")
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, hc.GetStatus(), "registry should be serving")
+
+	resp, err := pb.NewCustomerRegistryClient(conn).New(context.Background(), &pb.NewRequest{
+		CustomerInfo: &pb.NewRequest_PersonInfo{PersonInfo: &pb.PersonInfo{
+			GivenName:   "given-name",
+			FamilyName:  "family-name",
+			Ssn:         "SSN",
+			DateOfBirth: "1970-01-01",
+			Citizenship: "FI",
+		}},
+	})
+	assert.Nil(t, err, "New should succeed")
+	assert.NotZero(t, resp.GetCustomer().GetId(), "customer should get an ID")
+
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.Nil(t, err, "graceful shutdown should not fail")
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+}
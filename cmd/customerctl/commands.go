@@ -0,0 +1,371 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/pb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func newCmd(ctx context.Context, e *env, args []string) error {
+	if len(args) < 1 {
+		return usageError("customer kind missing")
+	}
+
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	info, err := infoFlags(fs, args[0], e.in)
+	if err != nil {
+		return err
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return usageError("%v", err)
+	}
+
+	m, err := info()
+	if err != nil {
+		return err
+	}
+
+	req := &pb.NewRequest{}
+	switch i := m.(type) {
+	case *pb.PersonInfo:
+		req.CustomerInfo = &pb.NewRequest_PersonInfo{PersonInfo: i}
+	case *pb.OrganizationInfo:
+		req.CustomerInfo = &pb.NewRequest_OrganizationInfo{OrganizationInfo: i}
+	case *pb.SoleTraderInfo:
+		req.CustomerInfo = &pb.NewRequest_SoleTraderInfo{SoleTraderInfo: i}
+	}
+
+	resp, err := e.client.New(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return printCustomers(e, []*pb.Customer{resp.GetCustomer()}, true)
+}
+
+func getCmd(ctx context.Context, e *env, args []string) error {
+	if len(args) != 1 {
+		return usageError("customer ID missing")
+	}
+
+	id, err := parseID(args[0])
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.client.Get(ctx, &pb.GetRequest{CustomerId: id})
+	if err != nil {
+		return err
+	}
+
+	return printCustomers(e, []*pb.Customer{resp.GetCustomer()}, true)
+}
+
+func updateInfoCmd(ctx context.Context, e *env, args []string) error {
+	if len(args) < 2 {
+		return usageError("customer ID and kind missing")
+	}
+
+	id, err := parseID(args[0])
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("update-info", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	mask := fs.String("mask", "", "comma separated fields to update, e.g. family_name,citizenship, all when empty")
+	info, err := infoFlags(fs, args[1], e.in)
+	if err != nil {
+		return err
+	}
+	if err := fs.Parse(args[2:]); err != nil {
+		return usageError("%v", err)
+	}
+
+	m, err := info()
+	if err != nil {
+		return err
+	}
+
+	req := &pb.UpdateInfoRequest{CustomerId: id}
+	if *mask != "" {
+		req.UpdateMask = &fieldmaskpb.FieldMask{Paths: strings.Split(*mask, ",")}
+	}
+	switch i := m.(type) {
+	case *pb.PersonInfo:
+		req.CustomerInfo = &pb.UpdateInfoRequest_PersonInfo{PersonInfo: i}
+	case *pb.OrganizationInfo:
+		req.CustomerInfo = &pb.UpdateInfoRequest_OrganizationInfo{OrganizationInfo: i}
+	case *pb.SoleTraderInfo:
+		req.CustomerInfo = &pb.UpdateInfoRequest_SoleTraderInfo{SoleTraderInfo: i}
+	}
+
+	resp, err := e.client.UpdateInfo(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return printCustomers(e, []*pb.Customer{resp.GetCustomer()}, true)
+}
+
+func convertTypeCmd(ctx context.Context, e *env, args []string) error {
+	if len(args) < 2 {
+		return usageError("customer ID and kind missing")
+	}
+
+	id, err := parseID(args[0])
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("convert-type", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	info, err := infoFlags(fs, args[1], e.in)
+	if err != nil {
+		return err
+	}
+	if err := fs.Parse(args[2:]); err != nil {
+		return usageError("%v", err)
+	}
+
+	m, err := info()
+	if err != nil {
+		return err
+	}
+
+	req := &pb.ConvertTypeRequest{CustomerId: id}
+	switch i := m.(type) {
+	case *pb.PersonInfo:
+		req.CustomerInfo = &pb.ConvertTypeRequest_PersonInfo{PersonInfo: i}
+	case *pb.OrganizationInfo:
+		req.CustomerInfo = &pb.ConvertTypeRequest_OrganizationInfo{OrganizationInfo: i}
+	case *pb.SoleTraderInfo:
+		req.CustomerInfo = &pb.ConvertTypeRequest_SoleTraderInfo{SoleTraderInfo: i}
+	}
+
+	resp, err := e.client.ConvertType(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return printCustomers(e, []*pb.Customer{resp.GetCustomer()}, true)
+}
+
+func setStateCmd(ctx context.Context, e *env, args []string) error {
+	if len(args) != 2 {
+		return usageError("customer ID and state missing")
+	}
+
+	id, err := parseID(args[0])
+	if err != nil {
+		return err
+	}
+
+	s, ok := pb.State_value[strings.ToUpper(lower(args[1]))]
+	if !ok {
+		return usageError("unknown state %q", args[1])
+	}
+
+	resp, err := e.client.SetState(ctx, &pb.SetStateRequest{CustomerId: id, State: pb.State(s)})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(e.out, resp.GetMsg())
+
+	return nil
+}
+
+func listCmd(ctx context.Context, e *env, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	size := fs.Uint("page-size", 0, "customers per page, zero for server default")
+	token := fs.Uint("page-token", 0, "ID of the last customer of the previous page")
+	all := fs.Bool("all", false, "fetch every page")
+	if err := fs.Parse(args); err != nil {
+		return usageError("%v", err)
+	}
+
+	var cs []*pb.Customer
+
+	err := pages(ctx, e.client, uint32(*size), uint32(*token), func(page []*pb.Customer, next uint32) bool {
+		cs = append(cs, page...)
+		if !*all && next != 0 {
+			fmt.Fprintf(e.errOut, "more customers available, continue with -page-token %d\n", next)
+		}
+		return *all
+	})
+	if err != nil {
+		return err
+	}
+
+	return printCustomers(e, cs, false)
+}
+
+func searchCmd(ctx context.Context, e *env, args []string) error {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	limit := fs.Uint("limit", 0, "maximum number of results, zero for server default")
+	if err := fs.Parse(args); err != nil {
+		return usageError("%v", err)
+	}
+
+	if fs.NArg() == 0 {
+		return usageError("query missing")
+	}
+
+	resp, err := e.client.Search(ctx, &pb.SearchRequest{Query: strings.Join(fs.Args(), " "), Limit: uint32(*limit)})
+	if err != nil {
+		return err
+	}
+
+	return printCustomers(e, resp.GetCustomers(), false)
+}
+
+func duplicatesCmd(ctx context.Context, e *env, args []string) error {
+	fs := flag.NewFlagSet("duplicates", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	minScore := fs.Float64("min-score", 0, "minimum score between 0 and 1, zero for server default")
+	limit := fs.Uint("limit", 0, "maximum number of pairs, zero for server default")
+	if err := fs.Parse(args); err != nil {
+		return usageError("%v", err)
+	}
+
+	resp, err := e.client.FindDuplicates(ctx, &pb.FindDuplicatesRequest{MinScore: *minScore, Limit: uint32(*limit)})
+	if err != nil {
+		return err
+	}
+
+	return printDuplicates(e, resp.GetDuplicates())
+}
+
+// importCmd creates a customer for every record, records hold person_info,
+// organization_info or sole_trader_info as written by export
+func importCmd(ctx context.Context, e *env, args []string) error {
+	if len(args) > 1 {
+		return usageError("too many arguments")
+	}
+
+	var path string
+	if len(args) == 1 {
+		path = args[0]
+	}
+
+	records, err := readRecords(path, e.in)
+	if err != nil {
+		return err
+	}
+
+	unmarshal := protojson.UnmarshalOptions{DiscardUnknown: true}
+
+	var failed int
+	for n, r := range records {
+		req := &pb.NewRequest{}
+		if err := unmarshal.Unmarshal(r, req); err != nil {
+			return errors.Wrapf(err, "record %d", n+1)
+		}
+
+		resp, err := e.client.New(ctx, req)
+		if err != nil {
+			failed++
+			fmt.Fprintf(e.out, "record %d: %v\n", n+1, err)
+			continue
+		}
+
+		fmt.Fprintf(e.out, "record %d: created %d\n", n+1, resp.GetCustomer().GetId())
+	}
+
+	if failed > 0 {
+		return errors.Newf("%d of %d records failed", failed, len(records))
+	}
+
+	return nil
+}
+
+// exportCmd writes every customer as JSON lines, or as a YAML document stream with -o yaml
+func exportCmd(ctx context.Context, e *env, args []string) error {
+	if len(args) > 1 {
+		return usageError("too many arguments")
+	}
+
+	w := e.out
+	if len(args) == 1 && args[0] != "-" {
+		f, err := os.Create(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	write := writeJSONLine
+	if e.format == "yaml" {
+		write = writeYAMLDoc
+	}
+
+	var werr error
+	err := pages(ctx, e.client, 0, 0, func(page []*pb.Customer, next uint32) bool {
+		for _, c := range page {
+			if werr = write(w, c); werr != nil {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	return werr
+}
+
+func writeJSONLine(w io.Writer, c *pb.Customer) error {
+	b, err := marshaler.Marshal(c)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", b)
+	return err
+}
+
+func writeYAMLDoc(w io.Writer, c *pb.Customer) error {
+	if _, err := fmt.Fprintln(w, "---"); err != nil {
+		return err
+	}
+	return printYAML(w, []*pb.Customer{c}, true)
+}
+
+// pages calls fn for every page until fn returns false or there are no more pages
+func pages(ctx context.Context, client pb.CustomerRegistryClient, size, token uint32, fn func([]*pb.Customer, uint32) bool) error {
+	for {
+		resp, err := client.List(ctx, &pb.ListRequest{PageSize: size, PageToken: token})
+		if err != nil {
+			return err
+		}
+
+		if !fn(resp.GetCustomers(), resp.GetNextPageToken()) || resp.GetNextPageToken() == 0 {
+			return nil
+		}
+
+		token = resp.GetNextPageToken()
+	}
+}
+
+func parseID(s string) (uint32, error) {
+	id, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, usageError("invalid customer ID %q", s)
+	}
+	return uint32(id), nil
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/nacobas/customer/pb"
+	"github.com/nacobas/customer/transport"
+	gdate "google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// infoFlags registers -f and field flags for kind, the returned func builds the info with
+// flag values overriding values read from the file
+func infoFlags(fs *flag.FlagSet, kind string, in io.Reader) (func() (proto.Message, error), error) {
+	file := fs.String("f", "", "JSON or YAML file with the info, - for stdin")
+
+	switch kind {
+	case "person":
+		pf := newPersonFlags(fs)
+
+		return func() (proto.Message, error) {
+			pi := &pb.PersonInfo{}
+			if err := readMessage(*file, in, pi); err != nil {
+				return nil, err
+			}
+			pf.set(&pi.GivenName, &pi.MiddleNames, &pi.FamilyNamePrefix, &pi.FamilyName, &pi.Ssn, &pi.Citizenship)
+			if err := setDate(&pi.BirthDate, &pi.DateOfBirth, "date-of-birth", *pf.dob); err != nil {
+				return nil, err
+			}
+			return pi, nil
+		}, nil
+	case "sole-trader":
+		pf := newPersonFlags(fs)
+		tradeName := fs.String("trade-name", "", "trade name")
+		businessID := fs.String("business-id", "", "business ID")
+
+		return func() (proto.Message, error) {
+			si := &pb.SoleTraderInfo{}
+			if err := readMessage(*file, in, si); err != nil {
+				return nil, err
+			}
+			pf.set(&si.GivenName, &si.MiddleNames, &si.FamilyNamePrefix, &si.FamilyName, &si.Ssn, &si.Citizenship)
+			set(&si.TradeName, *tradeName)
+			set(&si.BusinessId, *businessID)
+			if err := setDate(&si.BirthDate, &si.DateOfBirth, "date-of-birth", *pf.dob); err != nil {
+				return nil, err
+			}
+			return si, nil
+		}, nil
+	case "org":
+		name := fs.String("name", "", "organization name")
+		form := fs.String("form", "", "legal form")
+		legalID := fs.String("legal-id", "", "legal ID")
+		registered := fs.String("registration-date", "", "date of registration YYYY-MM-DD")
+		country := fs.String("registration-country", "", "ISO 3166-1 alpha-2 registration country")
+
+		return func() (proto.Message, error) {
+			oi := &pb.OrganizationInfo{}
+			if err := readMessage(*file, in, oi); err != nil {
+				return nil, err
+			}
+			set(&oi.Name, *name)
+			set(&oi.Form, *form)
+			set(&oi.LegalId, *legalID)
+			set(&oi.RegistrationCountry, *country)
+			if err := setDate(&oi.RegistrationDate, &oi.DateOfRegistration, "registration-date", *registered); err != nil {
+				return nil, err
+			}
+			return oi, nil
+		}, nil
+	}
+
+	return nil, usageError("unknown customer kind %q, want person, org or sole-trader", kind)
+}
+
+// personFlags are the field flags of persons and sole traders
+type personFlags struct {
+	givenName, middleNames, prefix, familyName, ssn, dob, citizenship *string
+}
+
+func newPersonFlags(fs *flag.FlagSet) *personFlags {
+	return &personFlags{
+		givenName:   fs.String("given-name", "", "given name"),
+		middleNames: fs.String("middle-names", "", "comma separated middle names"),
+		prefix:      fs.String("family-name-prefix", "", "family name prefix such as van der"),
+		familyName:  fs.String("family-name", "", "family name"),
+		ssn:         fs.String("ssn", "", "social security number"),
+		dob:         fs.String("date-of-birth", "", "date of birth YYYY-MM-DD"),
+		citizenship: fs.String("citizenship", "", "ISO 3166-1 alpha-2 citizenship"),
+	}
+}
+
+// set overrides the person fields with the flags given, the date of birth is set by setDate
+func (pf *personFlags) set(givenName *string, middleNames *[]string, prefix, familyName, ssn, citizenship *string) {
+	set(givenName, *pf.givenName)
+	if *pf.middleNames != "" {
+		*middleNames = strings.Split(*pf.middleNames, ",")
+	}
+	set(prefix, *pf.prefix)
+	set(familyName, *pf.familyName)
+	set(ssn, *pf.ssn)
+	set(citizenship, *pf.citizenship)
+}
+
+func set(field *string, v string) {
+	if v != "" {
+		*field = v
+	}
+}
+
+// setDate overrides a date with the value of flag name when given, the deprecated string
+// form read from a file is cleared so that it can not disagree
+func setDate(field **gdate.Date, deprecated *string, name, v string) error {
+	if v == "" {
+		return nil
+	}
+
+	d, err := transport.ParseDate(v)
+	if err != nil {
+		return usageError("-%s: %v", name, err)
+	}
+
+	*field = transport.ToPBDate(d)
+	*deprecated = ""
+	return nil
+}
+
+// readMessage fills m from a JSON or YAML file, an empty path leaves m untouched
+func readMessage(path string, in io.Reader, m proto.Message) error {
+	if path == "" {
+		return nil
+	}
+
+	b, err := readFile(path, in)
+	if err != nil {
+		return err
+	}
+
+	return unmarshalYAML(b, m, false)
+}
+
+func readFile(path string, in io.Reader) ([]byte, error) {
+	if path == "-" {
+		return ioutil.ReadAll(in)
+	}
+	return ioutil.ReadFile(path)
+}
+
+// unmarshalYAML decodes YAML, or JSON as its subset, into a protobuf message
+func unmarshalYAML(b []byte, m proto.Message, discardUnknown bool) error {
+	var v interface{}
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	j, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return protojson.UnmarshalOptions{DiscardUnknown: discardUnknown}.Unmarshal(j, m)
+}
+
+// readRecords splits an import file into records, JSON lines when the input starts with
+// '{' and a YAML document stream otherwise
+func readRecords(path string, in io.Reader) ([][]byte, error) {
+	if path == "" {
+		path = "-"
+	}
+
+	b, err := readFile(path, in)
+	if err != nil {
+		return nil, err
+	}
+
+	var records [][]byte
+
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+		sc := bufio.NewScanner(bytes.NewReader(b))
+		sc.Buffer(make([]byte, 64*1024), 1024*1024)
+		for sc.Scan() {
+			if line := bytes.TrimSpace(sc.Bytes()); len(line) > 0 {
+				records = append(records, append([]byte(nil), line...))
+			}
+		}
+		return records, sc.Err()
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	for {
+		var v interface{}
+		err := dec.Decode(&v)
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			continue
+		}
+		j, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, j)
+	}
+}
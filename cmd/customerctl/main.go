@@ -0,0 +1,199 @@
+// Command customerctl is a command line client for the CustomerRegistry gRPC API
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+var (
+	ErrUsage = errors.New("Invalid usage")
+)
+
+type globals struct {
+	addr    string
+	token   string
+	tenant  string
+	lang    string
+	useTLS  bool
+	caFile  string
+	output  string
+	timeout time.Duration
+}
+
+// env is what a command runs with
+type env struct {
+	client pb.CustomerRegistryClient
+	in     io.Reader
+	out    io.Writer
+	errOut io.Writer
+	format string
+}
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, e *env, args []string) error
+}
+
+var commands = map[string]command{
+	"new":          {"new person|org|sole-trader [-f FILE] [field flags]", newCmd},
+	"get":          {"get ID", getCmd},
+	"update-info":  {"update-info ID person|org|sole-trader [-f FILE] [-mask FIELDS] [field flags]", updateInfoCmd},
+	"convert-type": {"convert-type ID person|org|sole-trader [-f FILE] [field flags]", convertTypeCmd},
+	"set-state":    {"set-state ID prospect|active|passive", setStateCmd},
+	"list":         {"list [-page-size N] [-all]", listCmd},
+	"search":       {"search [-limit N] QUERY", searchCmd},
+	"duplicates":   {"duplicates [-min-score S] [-limit N]", duplicatesCmd},
+	"import":       {"import [FILE]", importCmd},
+	"export":       {"export [FILE]", exportCmd},
+}
+
+type dialer func(ctx context.Context, g globals) (*grpc.ClientConn, error)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr, dial))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer, dial dialer) int {
+	var g globals
+
+	fs := flag.NewFlagSet("customerctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&g.addr, "addr", envOr("CUSTOMERCTL_ADDR", "localhost:50051"), "registry address")
+	fs.StringVar(&g.token, "token", os.Getenv("CUSTOMERCTL_TOKEN"), "bearer token")
+	fs.StringVar(&g.tenant, "tenant", os.Getenv("CUSTOMERCTL_TENANT"), "tenant ID")
+	fs.StringVar(&g.lang, "lang", os.Getenv("CUSTOMERCTL_LANG"), "language of validation messages: en, fi or sv")
+	fs.BoolVar(&g.useTLS, "tls", false, "connect with TLS using system roots")
+	fs.StringVar(&g.caFile, "ca", "", "CA file for TLS, implies -tls")
+	fs.StringVar(&g.output, "o", "table", "output format: table, json or yaml")
+	fs.DurationVar(&g.timeout, "timeout", 30*time.Second, "timeout for the whole command")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: customerctl [flags] COMMAND [args]\n\ncommands:\n")
+		names := make([]string, 0, len(commands))
+		for name := range commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(stderr, "  %s\n", commands[name].usage)
+		}
+		fmt.Fprintf(stderr, "\nflags:\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fs.Usage()
+		return 2
+	}
+
+	if _, ok := formatters[g.output]; !ok {
+		fmt.Fprintf(stderr, "unknown output format %q\n", g.output)
+		return 2
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+	defer cancel()
+
+	conn, err := dial(ctx, g)
+	if err != nil {
+		fmt.Fprintf(stderr, "connect %s: %v\n", g.addr, err)
+		return 1
+	}
+	defer conn.Close()
+
+	ctx = outgoing(ctx, g)
+
+	e := &env{client: pb.NewCustomerRegistryClient(conn), in: stdin, out: stdout, errOut: stderr, format: g.output}
+
+	if err := cmd.run(ctx, e, fs.Args()[1:]); err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", fs.Arg(0), err)
+		if errors.Is(err, ErrUsage) {
+			fmt.Fprintf(stderr, "usage: customerctl %s\n", cmd.usage)
+			return 2
+		}
+		return 1
+	}
+
+	return 0
+}
+
+func dial(ctx context.Context, g globals) (*grpc.ClientConn, error) {
+	creds := grpc.WithInsecure()
+
+	if g.useTLS || g.caFile != "" {
+		tc := &tls.Config{MinVersion: tls.VersionTLS12}
+
+		if g.caFile != "" {
+			pem, err := ioutil.ReadFile(g.caFile)
+			if err != nil {
+				return nil, err
+			}
+			tc.RootCAs = x509.NewCertPool()
+			if !tc.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, errors.Newf("no certificates in %s", g.caFile)
+			}
+		}
+
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(tc))
+	}
+
+	return grpc.DialContext(ctx, g.addr, creds, grpc.WithBlock())
+}
+
+// outgoing adds credentials and tenant to request metadata
+func outgoing(ctx context.Context, g globals) context.Context {
+	var kv []string
+
+	if g.token != "" {
+		kv = append(kv, "authorization", "Bearer "+g.token)
+	}
+
+	if g.tenant != "" {
+		kv = append(kv, "x-tenant-id", g.tenant)
+	}
+
+	if g.lang != "" {
+		kv = append(kv, "accept-language", g.lang)
+	}
+
+	if len(kv) == 0 {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+func usageError(format string, args ...interface{}) error {
+	return errors.Mark(errors.Newf(format, args...), ErrUsage)
+}
+
+func lower(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
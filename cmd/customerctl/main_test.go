@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/nacobas/customer/dedup"
+	"github.com/nacobas/customer/pb"
+	"github.com/nacobas/customer/registry"
+	"github.com/nacobas/customer/repo/inmem"
+	"github.com/nacobas/customer/transport"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestCommands(t *testing.T) {
+	t.Parallel()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	pb.RegisterCustomerRegistryServer(srv, transport.NewGRPCServer(registry.NewService(inmem.NewRepo(),
+		registry.WithDuplicateFinder(dedup.NewEngine()))))
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	dial := func(ctx context.Context, g globals) (*grpc.ClientConn, error) {
+		return grpc.DialContext(ctx, "bufnet", grpc.WithInsecure(), grpc.WithContextDialer(
+			func(context.Context, string) (net.Conn, error) { return lis.Dial() }))
+	}
+
+	ctl := func(stdin string, args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := run(args, strings.NewReader(stdin), &stdout, &stderr, dial)
+		return code, stdout.String(), stderr.String()
+	}
+
+	code, out, _ := ctl("", "-o", "json", "new", "person",
+		"-given-name", "Anna", "-family-name", "Virtanen", "-ssn", "010170-123A",
+		"-date-of-birth", "1970-01-01", "-citizenship", "FI")
+	assert.Equal(t, 0, code, "new person")
+	assert.Contains(t, out, `"given_name": "Anna"`, "JSON output")
+
+	code, _, errOut := ctl("", "-lang", "fi", "new", "person", "-given-name", "Anna", "-date-of-birth", "1970-01-01")
+	assert.Equal(t, 1, code, "invalid person")
+	assert.Contains(t, errOut, "ssn: on pakollinen", "localised validation message")
+
+	code, _, errOut = ctl("", "new", "person", "-date-of-birth", "1.1.1970")
+	assert.Equal(t, 2, code, "malformed date")
+	assert.Contains(t, errOut, "-date-of-birth", "malformed date flag named")
+
+	orgYAML := `
+name: Acme
+form: Oy
+legal_id: 1234567-8
+date_of_registration: "2000-01-01"
+registration_country: FI
+`
+	code, out, _ = ctl(orgYAML, "-o", "yaml", "new", "org", "-f", "-")
+	assert.Equal(t, 0, code, "new org from file")
+	assert.Contains(t, out, "legal_id: 1234567-8", "YAML output")
+
+	code, out, _ = ctl("", "list")
+	assert.Equal(t, 0, code, "list")
+	assert.Contains(t, out, "Anna Virtanen", "table output")
+	assert.Contains(t, out, "Acme", "table output")
+
+	code, out, _ = ctl("", "search", "acme")
+	assert.Equal(t, 0, code, "search")
+	assert.NotContains(t, out, "Anna", "search should filter")
+
+	code, export, _ := ctl("", "export")
+	assert.Equal(t, 0, code, "export")
+	assert.Len(t, strings.Split(strings.TrimSpace(export), "\n"), 2, "one line per customer")
+
+	id := strings.Fields(strings.Split(out, "\n")[1])[0]
+
+	code, out, _ = ctl("", "set-state", id, "active")
+	assert.Equal(t, 0, code, "set-state")
+
+	code, _, errOut = ctl("", "convert-type", id, "person", "-given-name", "Acme", "-family-name", "Oy", "-ssn", "010180-123A",
+		"-date-of-birth", "1980-01-01", "-citizenship", "FI")
+	assert.Equal(t, 1, code, "convert-type of an active customer")
+	assert.Contains(t, errOut, "not a prospect", "convert-type error")
+
+	code, out, _ = ctl("", "update-info", id, "org", "-name", "Acme Group", "-form", "Oy", "-legal-id", "1234567-8",
+		"-registration-date", "2000-01-01", "-registration-country", "FI")
+	assert.Equal(t, 0, code, "update-info")
+	assert.Contains(t, out, "Acme Group", "updated name")
+
+	code, out, _ = ctl("", "-o", "json", "update-info", id, "org", "-mask", "form", "-form", "Ab")
+	assert.Equal(t, 0, code, "update-info with mask")
+	assert.Contains(t, out, `"name": "Acme Group"`, "unmasked field should be kept")
+	assert.Contains(t, out, `"form": "Ab"`, "masked field should be updated")
+
+	code, out, _ = ctl("", "get", id)
+	assert.Equal(t, 0, code, "get")
+	assert.Contains(t, out, "ACTIVE", "state should be set")
+
+	imported := `{"person_info": {"given_name": "Ben", "family_name": "Berg", "ssn": "020280-456B", "date_of_birth": "1980-02-02", "citizenship": "SE"}}
+{"organization_info": {"name": "Bad", "form": "Oy", "legal_id": "", "date_of_registration": "2000-01-01", "registration_country": "FI"}}
+`
+	code, out, _ = ctl(imported, "import")
+	assert.Equal(t, 1, code, "import should report failures")
+	assert.Contains(t, out, "record 1: created", "valid record should be imported")
+	assert.Contains(t, out, "record 2: rpc error", "invalid record should be reported")
+
+	// re-importing an export conflicts on SSN and legal ID
+	code, _, _ = ctl(export, "import")
+	assert.Equal(t, 1, code, "duplicates should fail")
+
+	code, out, _ = ctl("", "new", "person", "-given-name", "Ana", "-family-name", "Virtanen", "-ssn", "010170-123B",
+		"-date-of-birth", "1970-01-01", "-citizenship", "FI")
+	assert.Equal(t, 0, code, "new probable duplicate")
+
+	code, out, _ = ctl("", "duplicates")
+	assert.Equal(t, 0, code, "duplicates")
+	assert.Contains(t, out, "same date of birth", "report should explain the score")
+
+	code, out, _ = ctl("", "-o", "json", "duplicates", "-min-score", "0.99")
+	assert.Equal(t, 0, code, "duplicates with min score")
+	assert.NotContains(t, out, "same date of birth", "min score should filter")
+
+	code, out, _ = ctl("", "new", "sole-trader", "-given-name", "Anna", "-family-name", "Virtanen", "-ssn", "010170-123A",
+		"-date-of-birth", "1970-01-01", "-citizenship", "FI", "-trade-name", "Anna Design", "-business-id", "7654321-0")
+	assert.Equal(t, 0, code, "new sole trader")
+	assert.Contains(t, out, "sole-trader", "table output")
+	assert.Contains(t, out, "Anna Design", "trade name shown")
+
+	code, _, stderr := ctl("", "get", "not-a-number")
+	assert.Equal(t, 2, code, "usage error")
+	assert.Contains(t, stderr, "usage: customerctl get ID", "usage should be printed")
+}
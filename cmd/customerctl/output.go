@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/nacobas/customer/pb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+)
+
+// formatters print one customer or a list of customers
+var formatters = map[string]func(w io.Writer, cs []*pb.Customer, single bool) error{
+	"table": printTable,
+	"json":  printJSON,
+	"yaml":  printYAML,
+}
+
+func printCustomers(e *env, cs []*pb.Customer, single bool) error {
+	return formatters[e.format](e.out, cs, single)
+}
+
+var marshaler = protojson.MarshalOptions{UseProtoNames: true}
+
+func printTable(w io.Writer, cs []*pb.Customer, single bool) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "ID\tTYPE\tSTATE\tNAME\tIDENTIFIER\tRISK")
+	for _, c := range cs {
+		typ, name, ident := "", "", ""
+		switch {
+		case c.GetPersonInfo() != nil:
+			pi := c.GetPersonInfo()
+			typ, name, ident = "person", fullName(pi), pi.GetSsn()
+		case c.GetOrganizationInfo() != nil:
+			oi := c.GetOrganizationInfo()
+			typ, name, ident = "org", oi.GetName(), oi.GetLegalId()
+		case c.GetSoleTraderInfo() != nil:
+			si := c.GetSoleTraderInfo()
+			typ, name, ident = "sole-trader", si.GetTradeName(), si.GetBusinessId()
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n", c.GetId(), typ, c.GetState(), name, ident, c.GetRisk().GetRating())
+	}
+
+	return tw.Flush()
+}
+
+// fullName joins the name parts of a person in display order
+func fullName(pi *pb.PersonInfo) string {
+	parts := append([]string{pi.GetGivenName()}, pi.GetMiddleNames()...)
+	if pi.GetFamilyNamePrefix() != "" {
+		parts = append(parts, pi.GetFamilyNamePrefix())
+	}
+	return strings.Join(append(parts, pi.GetFamilyName()), " ")
+}
+
+func printJSON(w io.Writer, cs []*pb.Customer, single bool) error {
+	raw, err := jsonValue(cs, single)
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(w, raw)
+}
+
+func writeJSON(w io.Writer, raw []byte) error {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, raw, "", "  "); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func printYAML(w io.Writer, cs []*pb.Customer, single bool) error {
+	raw, err := jsonValue(cs, single)
+	if err != nil {
+		return err
+	}
+
+	return writeYAML(w, raw)
+}
+
+func writeYAML(w io.Writer, raw []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return err
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// printDuplicates prints the duplicate report, highest score first
+func printDuplicates(e *env, ds []*pb.Duplicate) error {
+	if e.format == "table" {
+		tw := tabwriter.NewWriter(e.out, 0, 4, 2, ' ', 0)
+
+		fmt.Fprintln(tw, "ID\tDUPLICATE\tSCORE\tREASONS")
+		for _, d := range ds {
+			fmt.Fprintf(tw, "%d\t%d\t%.2f\t%s\n", d.GetCustomerId(), d.GetDuplicateId(), d.GetScore(), strings.Join(d.GetReasons(), ", "))
+		}
+
+		return tw.Flush()
+	}
+
+	raw, err := marshaler.Marshal(&pb.FindDuplicatesResponse{Duplicates: ds})
+	if err != nil {
+		return err
+	}
+
+	if e.format == "yaml" {
+		return writeYAML(e.out, raw)
+	}
+	return writeJSON(e.out, raw)
+}
+
+func jsonValue(cs []*pb.Customer, single bool) ([]byte, error) {
+	if single && len(cs) == 1 {
+		return marshaler.Marshal(cs[0])
+	}
+
+	buf := bytes.NewBufferString("[")
+	for i, c := range cs {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		b, err := marshaler.Marshal(c)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+	buf.WriteByte(']')
+
+	return buf.Bytes(), nil
+}
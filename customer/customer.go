@@ -1,7 +1,11 @@
 package customer
 
 import (
+	crand "crypto/rand"
+	"encoding/hex"
 	"math/rand"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -11,6 +15,14 @@ import (
 
 var (
 	ErrTypeNotEqual = errors.New("Type not equal")
+	ErrErased       = errors.New("Customer erased")
+	ErrNotErasable  = errors.New("Only persons can be erased")
+	ErrUnknownField = errors.New("Unknown field")
+	ErrMerged       = errors.New("Customer merged")
+	ErrNotMerged    = errors.New("Customer not merged")
+	ErrNotProspect  = errors.New("Customer not a prospect")
+	ErrSameType     = errors.New("Type not changed")
+	ErrTooYoung     = errors.New("Customer too young to become active")
 )
 
 func New(id uint32, i Info) *Customer {
@@ -36,11 +48,29 @@ type Customer struct {
 	ID    uint32 `validate:"required"`
 	State State  `validate:"min=1,max=3"`
 	Info  `validate:"required"`
+	Risk  Risk
+	// Erased customers keep their ID and State for referential integrity only
+	Erased bool
+	// ErasureDue is set when erasure was requested but deferred by a legal hold
+	ErasureDue time.Time
+	// MergedInto is the ID of the survivor of a merge, the customer is kept as a redirect
+	MergedInto uint32
+	MergedAt   time.Time
+	// Profile is the validation profile the info was last validated against
+	Profile ProfileRef
 }
 
 func (c *Customer) UpdateInfo(i Info) error {
 
-	if c.Type() != i.Type() {
+	if c.Erased {
+		return ErrErased
+	}
+
+	if c.MergedInto != 0 {
+		return ErrMerged
+	}
+
+	if !convertible(c.Info, i) {
 		return ErrTypeNotEqual
 	}
 
@@ -49,16 +79,217 @@ func (c *Customer) UpdateInfo(i Info) error {
 	return nil
 }
 
+// convertible reports whether info may be replaced by i. Besides keeping the type, a
+// private customer may start trading as a sole trader and a sole trader may stop trading,
+// as long as the person, identified by SSN, stays the same.
+func convertible(info, i Info) bool {
+	if info.Type() == i.Type() {
+		return true
+	}
+
+	from, ok := PersonOf(info)
+	if !ok {
+		return false
+	}
+
+	to, ok := PersonOf(i)
+	if !ok {
+		return false
+	}
+
+	return CanonicalID(from.SSN) == CanonicalID(to.SSN)
+}
+
+// SetState moves the customer to state s, persons and sole traders become Active only at the
+// minimum age of r
+func (c *Customer) SetState(s State, r AgeRules) error {
+
+	if c.MergedInto != 0 {
+		return ErrMerged
+	}
+
+	if s == Active && c.State != Active && !c.Erased {
+		if pi, ok := PersonOf(c.Info); ok && r.MinActiveAge > 0 && Age(pi.DateOfBirth.ToTime(), time.Now()) < r.MinActiveAge {
+			return ErrTooYoung
+		}
+	}
+
+	c.State = s
+
+	return nil
+}
+
+// ConvertType replaces the info with info of another type keeping the customer ID. Only
+// prospects can be converted, nothing has been agreed with them under the old type yet.
+func (c *Customer) ConvertType(i Info) error {
+
+	if c.Erased {
+		return ErrErased
+	}
+
+	if c.MergedInto != 0 {
+		return ErrMerged
+	}
+
+	if c.State != Prospect {
+		return ErrNotProspect
+	}
+
+	if c.Type() == i.Type() {
+		return ErrSameType
+	}
+
+	c.Info = i
+
+	return nil
+}
+
+// MergeInfo returns a copy of the customer info with the named fields taken from i, fields
+// are named by their JSON names. The customer is not modified.
+func (c *Customer) MergeInfo(i Info, fields []string) (Info, error) {
+
+	if c.Erased {
+		return nil, ErrErased
+	}
+
+	if c.MergedInto != 0 {
+		return nil, ErrMerged
+	}
+
+	if c.Type() != i.Type() {
+		return nil, ErrTypeNotEqual
+	}
+
+	dst := reflect.New(reflect.TypeOf(c.Info).Elem())
+	dst.Elem().Set(reflect.ValueOf(c.Info).Elem())
+	src := reflect.ValueOf(i).Elem()
+
+	for _, f := range fields {
+		idx, ok := fieldIndex(src.Type(), f)
+		if !ok {
+			return nil, errors.Wrapf(ErrUnknownField, "%s has no field %q", i.Type(), f)
+		}
+		dst.Elem().Field(idx).Set(src.Field(idx))
+	}
+
+	return dst.Interface().(Info), nil
+}
+
+func fieldIndex(t reflect.Type, name string) (int, bool) {
+	for idx := 0; idx < t.NumField(); idx++ {
+		if strings.Split(t.Field(idx).Tag.Get("json"), ",")[0] == name {
+			return idx, true
+		}
+	}
+	return 0, false
+}
+
+// Erase replaces personal fields with irreversible tombstones
+func (c *Customer) Erase() error {
+
+	switch c.Info.(type) {
+	case *PersonInfo:
+		c.Info = &PersonInfo{
+			GivenName:  tombstone(),
+			FamilyName: tombstone(),
+			SSN:        tombstone(),
+		}
+	case *SoleTraderInfo:
+		// the trade name and business ID identify the person as well
+		c.Info = &SoleTraderInfo{
+			GivenName:  tombstone(),
+			FamilyName: tombstone(),
+			SSN:        tombstone(),
+			TradeName:  tombstone(),
+			BusinessID: tombstone(),
+		}
+	default:
+		return ErrNotErasable
+	}
+	// the assessment may explain the rating with personal data
+	c.Risk = Risk{Rating: c.Risk.Rating}
+	c.Erased = true
+	c.ErasureDue = time.Time{}
+
+	return nil
+}
+
+// MergeInto makes the customer a redirect to the survivor t, both must be of the same type
+// and neither erased nor merged
+func (c *Customer) MergeInto(t *Customer, at time.Time) error {
+
+	if c.Erased || t.Erased {
+		return ErrErased
+	}
+
+	if c.MergedInto != 0 || t.MergedInto != 0 {
+		return ErrMerged
+	}
+
+	if c.Type() != t.Type() {
+		return ErrTypeNotEqual
+	}
+
+	c.MergedInto = t.ID
+	c.MergedAt = at
+
+	return nil
+}
+
+// Unmerge turns a redirect back into a customer of its own
+func (c *Customer) Unmerge() error {
+
+	if c.MergedInto == 0 {
+		return ErrNotMerged
+	}
+
+	if c.Erased {
+		return ErrErased
+	}
+
+	c.MergedInto = 0
+	c.MergedAt = time.Time{}
+
+	return nil
+}
+
+// tombstone is random so the original value can not be recovered by brute force
+func tombstone() string {
+	b := make([]byte, 16)
+	if _, err := crand.Read(b); err != nil {
+		panic(err)
+	}
+	return "erased-" + hex.EncodeToString(b)
+}
+
 type Info interface {
 	Type() CustomerType
+	// Normalise brings the info to its display form in place
+	Normalise(c Casing)
+	// CanonicalName is the name compared by search
+	CanonicalName() string
 }
 
+// PersonInfo holds the name in parts: "Anna Maria van der Berg" is GivenName Anna,
+// MiddleNames [Maria], FamilyNamePrefix "van der" and FamilyName Berg. The prefix is kept
+// apart because it is ignored when sorting by family name in many countries.
 type PersonInfo struct {
-	GivenName   string    `validate:"person-name"`
-	FamilyName  string    `validate:"person-name"`
-	SSN         string    `validate:"required"`
-	DateOfBirth date.Date `validate:"required,before"`
-	Citizenship string    `validate:"required,iso3166_1_alpha2"`
+	GivenName        string    `validate:"person-name" json:"given_name"`
+	MiddleNames      []string  `validate:"dive,person-name" json:"middle_names"`
+	FamilyNamePrefix string    `validate:"omitempty,person-name" json:"family_name_prefix"`
+	FamilyName       string    `validate:"person-name" json:"family_name"`
+	SSN              string    `validate:"required" json:"ssn"`
+	DateOfBirth      date.Date `validate:"required,before,max_age" json:"date_of_birth"`
+	Citizenship      string    `validate:"required,iso3166_1_alpha2" json:"citizenship"`
+}
+
+// FullName joins the name parts in display order
+func (pi *PersonInfo) FullName() string {
+	parts := append([]string{pi.GivenName}, pi.MiddleNames...)
+	if pi.FamilyNamePrefix != "" {
+		parts = append(parts, pi.FamilyNamePrefix)
+	}
+	return strings.Join(append(parts, pi.FamilyName), " ")
 }
 
 func (pi *PersonInfo) Type() CustomerType {
@@ -66,22 +297,65 @@ func (pi *PersonInfo) Type() CustomerType {
 }
 
 type OrganizationInfo struct {
-	Name                string    `validate:"org-name"`
-	Form                string    `validate:"required"`
-	LeagalID            string    `validate:"required"`
-	RegistrationDate    date.Date `validate:"required,before"`
-	RegistrationCountry string    `validate:"required,iso3166_1_alpha2"`
+	Name                string    `validate:"org-name" json:"name"`
+	Form                string    `validate:"required" json:"form"`
+	LeagalID            string    `validate:"required" json:"legal_id"`
+	RegistrationDate    date.Date `validate:"required,before,min_registration" json:"registration_date"`
+	RegistrationCountry string    `validate:"required,iso3166_1_alpha2" json:"registration_country"`
 }
 
 func (oi *OrganizationInfo) Type() CustomerType {
 	return Organization
 }
 
+// SoleTraderInfo is a self-employed person trading in their own name: the identity of the
+// person as in PersonInfo plus the trade name and business ID of the business.
+type SoleTraderInfo struct {
+	GivenName        string    `validate:"person-name" json:"given_name"`
+	MiddleNames      []string  `validate:"dive,person-name" json:"middle_names"`
+	FamilyNamePrefix string    `validate:"omitempty,person-name" json:"family_name_prefix"`
+	FamilyName       string    `validate:"person-name" json:"family_name"`
+	SSN              string    `validate:"required" json:"ssn"`
+	DateOfBirth      date.Date `validate:"required,before,max_age" json:"date_of_birth"`
+	Citizenship      string    `validate:"required,iso3166_1_alpha2" json:"citizenship"`
+	TradeName        string    `validate:"org-name" json:"trade_name"`
+	BusinessID       string    `validate:"required" json:"business_id"`
+}
+
+func (si *SoleTraderInfo) Type() CustomerType {
+	return SoleTrader
+}
+
+// Person returns the person trading as the sole trader
+func (si *SoleTraderInfo) Person() *PersonInfo {
+	return &PersonInfo{
+		GivenName:        si.GivenName,
+		MiddleNames:      si.MiddleNames,
+		FamilyNamePrefix: si.FamilyNamePrefix,
+		FamilyName:       si.FamilyName,
+		SSN:              si.SSN,
+		DateOfBirth:      si.DateOfBirth,
+		Citizenship:      si.Citizenship,
+	}
+}
+
+// PersonOf returns the person of private customer and sole trader info
+func PersonOf(i Info) (*PersonInfo, bool) {
+	switch i := i.(type) {
+	case *PersonInfo:
+		return i, true
+	case *SoleTraderInfo:
+		return i.Person(), true
+	}
+	return nil, false
+}
+
 type CustomerType int32
 
 const (
 	Private CustomerType = iota + 1
 	Organization
+	SoleTrader
 )
 
 type State int32
@@ -91,3 +365,53 @@ const (
 	Active
 	Passive
 )
+
+type RiskRating int32
+
+const (
+	LowRisk RiskRating = iota + 1
+	MediumRisk
+	HighRisk
+)
+
+func (r RiskRating) String() string {
+	switch r {
+	case LowRisk:
+		return "Low"
+	case MediumRisk:
+		return "Medium"
+	case HighRisk:
+		return "High"
+	}
+	return "Unrated"
+}
+
+// Risk is the outcome of a risk assessment, Reasons explain how the rating was reached
+type Risk struct {
+	Rating  RiskRating
+	Reasons []string
+}
+
+func (t CustomerType) String() string {
+	switch t {
+	case Private:
+		return "Private"
+	case Organization:
+		return "Organization"
+	case SoleTrader:
+		return "SoleTrader"
+	}
+	return "Unknown"
+}
+
+func (s State) String() string {
+	switch s {
+	case Prospect:
+		return "Prospect"
+	case Active:
+		return "Active"
+	case Passive:
+		return "Passive"
+	}
+	return "Unknown"
+}
@@ -1,11 +1,16 @@
 package customer
 
 import (
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
 )
 
 func TestNewWithRandom(t *testing.T) {
@@ -58,7 +63,7 @@ func TestUpdateInfo(t *testing.T) {
 		{
 			desc: "update person info",
 			c:    &Customer{State: 1, Info: testPerson(t)},
-			info: &PersonInfo{"new-name", "new-name", "new-SSN", parseDate(t, "2020-01-01"), "FI"},
+			info: &PersonInfo{GivenName: "new-name", FamilyName: "new-name", SSN: "new-SSN", DateOfBirth: parseDate(t, "2020-01-01"), Citizenship: "FI"},
 			err:  nil,
 		},
 		{
@@ -76,7 +81,29 @@ func TestUpdateInfo(t *testing.T) {
 		{
 			desc: "try org with person",
 			c:    &Customer{State: 1, Info: testOrg(t)},
-			info: &PersonInfo{"new-name", "new-name", "new-SSN", parseDate(t, "2020-01-01"), "FI"},
+			info: &PersonInfo{GivenName: "new-name", FamilyName: "new-name", SSN: "new-SSN", DateOfBirth: parseDate(t, "2020-01-01"), Citizenship: "FI"},
+			err:  ErrTypeNotEqual,
+		},
+		{
+			desc: "person starts trading",
+			c:    &Customer{State: 1, Info: testPerson(t)},
+			info: testSoleTrader(t),
+		},
+		{
+			desc: "sole trader stops trading",
+			c:    &Customer{State: 1, Info: testSoleTrader(t)},
+			info: testPerson(t),
+		},
+		{
+			desc: "try sole trader of another person",
+			c:    &Customer{State: 1, Info: testPerson(t)},
+			info: &SoleTraderInfo{GivenName: "given-name", FamilyName: "family-name", SSN: "other-SSN", TradeName: "trade-name", BusinessID: "business-id"},
+			err:  ErrTypeNotEqual,
+		},
+		{
+			desc: "try sole trader with org",
+			c:    &Customer{State: 1, Info: testSoleTrader(t)},
+			info: testOrg(t),
 			err:  ErrTypeNotEqual,
 		},
 	}
@@ -96,6 +123,126 @@ func TestUpdateInfo(t *testing.T) {
 	}
 }
 
+func TestConvertType(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+		c    *Customer
+		info Info
+		err  error
+	}{
+		{
+			desc: "person to org",
+			c:    &Customer{ID: 1, State: Prospect, Info: testPerson(t)},
+			info: testOrg(t),
+		},
+		{
+			desc: "org to sole trader",
+			c:    &Customer{ID: 1, State: Prospect, Info: testOrg(t)},
+			info: testSoleTrader(t),
+		},
+		{
+			desc: "try active customer",
+			c:    &Customer{ID: 1, State: Active, Info: testPerson(t)},
+			info: testOrg(t),
+			err:  ErrNotProspect,
+		},
+		{
+			desc: "try same type",
+			c:    &Customer{ID: 1, State: Prospect, Info: testPerson(t)},
+			info: testPerson(t),
+			err:  ErrSameType,
+		},
+		{
+			desc: "try erased",
+			c:    &Customer{ID: 1, State: Prospect, Erased: true, Info: testPerson(t)},
+			info: testOrg(t),
+			err:  ErrErased,
+		},
+		{
+			desc: "try merged",
+			c:    &Customer{ID: 1, State: Prospect, MergedInto: 2, Info: testPerson(t)},
+			info: testOrg(t),
+			err:  ErrMerged,
+		},
+	}
+	for i := range testCases {
+		tC := testCases[i]
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := tC.c.ConvertType(tC.info)
+
+			if tC.err == nil {
+				assert.Nil(t, err, "error should be nil")
+				assert.Equal(t, tC.info, tC.c.Info, "info should be replaced")
+				assert.Equal(t, uint32(1), tC.c.ID, "ID should be kept")
+			} else {
+				assert.True(t, errors.Is(err, tC.err), "Expected error should be found in the chain")
+			}
+		})
+	}
+}
+
+func TestMergeInfo(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc   string
+		c      *Customer
+		info   Info
+		fields []string
+		want   Info
+		err    error
+	}{
+		{
+			desc:   "merge person fields",
+			c:      &Customer{State: 1, Info: testPerson(t)},
+			info:   &PersonInfo{GivenName: "new-name", SSN: "ignored"},
+			fields: []string{"given_name"},
+			want:   &PersonInfo{GivenName: "new-name", FamilyName: "family-name", SSN: "SSN", DateOfBirth: parseDate(t, "1970-01-01"), Citizenship: "US"},
+		},
+		{
+			desc:   "merge org fields",
+			c:      &Customer{State: 1, Info: testOrg(t)},
+			info:   &OrganizationInfo{Form: "Oy", RegistrationCountry: "FI"},
+			fields: []string{"form", "registration_country"},
+			want:   &OrganizationInfo{"org-name", "Oy", "legal-id", parseDate(t, "1970-01-01"), "FI"},
+		},
+		{
+			desc:   "unknown field",
+			c:      &Customer{State: 1, Info: testPerson(t)},
+			info:   &PersonInfo{},
+			fields: []string{"phone"},
+			err:    ErrUnknownField,
+		},
+		{
+			desc:   "type mismatch",
+			c:      &Customer{State: 1, Info: testPerson(t)},
+			info:   &OrganizationInfo{},
+			fields: []string{"name"},
+			err:    ErrTypeNotEqual,
+		},
+	}
+	for i := range testCases {
+		tC := testCases[i]
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tC.c.MergeInfo(tC.info, tC.fields)
+
+			if tC.err == nil {
+				assert.Nil(t, err, "error should be nil")
+				assert.Equal(t, tC.want, got)
+				assert.NotEqual(t, tC.want, tC.c.Info, "customer should not be modified")
+			} else {
+				assert.True(t, errors.Is(err, tC.err), "Expected error should be found in the chain")
+			}
+		})
+	}
+}
+
 func TestCustomerValidations(t *testing.T) {
 	t.Parallel()
 
@@ -157,8 +304,88 @@ func TestPersonValidations(t *testing.T) {
 	}
 }
 
+func TestPersonNames(t *testing.T) {
+	t.Parallel()
+
+	v := NewValidator()
+
+	testCases := []struct {
+		desc  string
+		name  string
+		valid bool
+	}{
+		{desc: "single letter", name: "A", valid: true},
+		{desc: "hyphen", name: "Anna-Liisa", valid: true},
+		{desc: "apostrophe", name: "O'Brien", valid: true},
+		{desc: "typographic apostrophe", name: "D’Angelo", valid: true},
+		{desc: "space", name: "Anne Marie", valid: true},
+		{desc: "combining mark", name: "Jose\u0301", valid: true},
+		{desc: "Cyrillic", name: "Иванов", valid: true},
+		{desc: "Han", name: "王", valid: true},
+		{desc: "Devanagari with vowel signs", name: "दीपिका", valid: true},
+		{desc: "empty", name: ""},
+		{desc: "digit", name: "Anna2"},
+		{desc: "leading separator", name: "-Anna"},
+		{desc: "trailing separator", name: "Anna'"},
+		{desc: "double separator", name: "Anne  Marie"},
+		{desc: "leading mark", name: "\u0301Jose"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			pi := testPerson(t)
+			pi.GivenName = tC.name
+
+			err := v.Struct(pi)
+
+			assert.Equal(t, tC.valid, err == nil, "name validity")
+		})
+	}
+
+	pi := testPerson(t)
+	pi.MiddleNames = []string{"Maria", "1"}
+	assert.NotNil(t, v.Struct(pi), "middle names should be validated")
+
+	pi = testPerson(t)
+	pi.GivenName, pi.MiddleNames, pi.FamilyNamePrefix, pi.FamilyName = "Anna", []string{"Maria"}, "van der", "Berg"
+	assert.Nil(t, v.Struct(pi), "error should be nil")
+	assert.Equal(t, "Anna Maria van der Berg", pi.FullName(), "full name in display order")
+}
+
+func TestTransliterate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		want string
+	}{
+		{name: "Müller", want: "muller"},
+		{name: "Ærø Łukasz", want: "aero lukasz"},
+		{name: "O'Brien", want: "obrien"},
+		{name: "Иванов", want: "ivanov"},
+		{name: "Παπαδόπουλος", want: "papadopoulos"},
+		{name: "王", want: "王"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			assert.Equal(t, tC.want, SearchKey(tC.name), "search key")
+		})
+	}
+}
+
 func testPerson(t *testing.T) *PersonInfo {
-	return &PersonInfo{"given-name", "family-name", "SSN", parseDate(t, "1970-01-01"), "US"}
+	return &PersonInfo{GivenName: "given-name", FamilyName: "family-name", SSN: "SSN", DateOfBirth: parseDate(t, "1970-01-01"), Citizenship: "US"}
+}
+
+func testSoleTrader(t *testing.T) *SoleTraderInfo {
+	return &SoleTraderInfo{
+		GivenName:   "given-name",
+		FamilyName:  "family-name",
+		SSN:         "SSN",
+		DateOfBirth: parseDate(t, "1970-01-01"),
+		Citizenship: "US",
+		TradeName:   "trade-name",
+		BusinessID:  "business-id",
+	}
 }
 
 func testOrg(t *testing.T) *OrganizationInfo {
@@ -172,3 +399,281 @@ func parseDate(t *testing.T, datestr string) date.Date {
 	}
 	return d
 }
+
+func TestNormalise(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		given     string
+		casing    Casing
+		want      string
+		canonical string
+	}{
+		{
+			desc:      "decomposed to composed",
+			given:     "A\u0308ino",
+			want:      "Äino",
+			canonical: "äino",
+		},
+		{
+			desc:      "white space collapsed",
+			given:     " \tanna  liisa ",
+			want:      "anna liisa",
+			canonical: "anna liisa",
+		},
+		{
+			desc:      "title case",
+			given:     "aNNA-liisa",
+			casing:    TitleCase,
+			want:      "Anna-Liisa",
+			canonical: "anna-liisa",
+		},
+		{
+			desc:      "upper case",
+			given:     "straße",
+			casing:    UpperCase,
+			want:      "STRASSE",
+			canonical: "strasse",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			pi := testPerson(t)
+			pi.GivenName = tC.given
+
+			pi.Normalise(tC.casing)
+
+			assert.Equal(t, tC.want, pi.GivenName, "display form")
+			assert.Equal(t, tC.canonical, Canonical(pi.GivenName), "canonical form")
+		})
+	}
+
+	assert.Equal(t, "1234567-8", CanonicalID(" 1234567 -8"), "identifier without white space")
+	assert.Equal(t, "010170-123A", CanonicalID("010170-123a"), "identifier upper cased")
+
+	_, err := ParseCasing("lower")
+	assert.True(t, errors.Is(err, ErrUnknownCasing), "Expected error should be found in the chain")
+}
+
+func TestSoleTrader(t *testing.T) {
+	t.Parallel()
+
+	si := testSoleTrader(t)
+
+	assert.Nil(t, NewValidator().Struct(si), "error should be nil")
+	assert.Equal(t, SoleTrader, si.Type(), "type")
+
+	pi, ok := PersonOf(si)
+	assert.True(t, ok, "sole trader is a person")
+	assert.Equal(t, testPerson(t), pi, "person of the sole trader")
+
+	_, ok = PersonOf(testOrg(t))
+	assert.False(t, ok, "organization is not a person")
+
+	c := New(1, si)
+	assert.Nil(t, c.Erase(), "sole traders are erasable")
+	assert.NotEqual(t, "business-id", c.Info.(*SoleTraderInfo).BusinessID, "business ID erased")
+	assert.Equal(t, SoleTrader, c.Type(), "erased sole trader keeps its type")
+}
+
+func TestProfileValidator(t *testing.T) {
+	t.Parallel()
+
+	p := Profile{
+		Name:                  "fi",
+		Version:               2,
+		Required:              []string{"middle_names", "form"},
+		MinAge:                18,
+		Citizenships:          []string{"FI", "SE"},
+		RegistrationCountries: []string{"FI"},
+	}
+
+	v, err := NewProfileValidator(p, DefaultAgeRules)
+	assert.Nil(t, err, "error should be nil")
+
+	minor := &PersonInfo{GivenName: "given-name", MiddleNames: []string{"middle-name"}, FamilyName: "family-name", SSN: "SSN",
+		DateOfBirth: date.Date{Time: time.Now().AddDate(-17, 0, 0)}, Citizenship: "FI"}
+
+	testCases := []struct {
+		desc string
+		info Info
+		tags []string
+	}{
+		{
+			desc: "valid person",
+			info: &PersonInfo{GivenName: "given-name", MiddleNames: []string{"middle-name"}, FamilyName: "family-name", SSN: "SSN",
+				DateOfBirth: parseDate(t, "1970-01-01"), Citizenship: "SE"},
+		},
+		{
+			desc: "person fails every rule",
+			info: testPerson(t),
+			tags: []string{"required", "oneof"},
+		},
+		{
+			desc: "empty middle names",
+			info: &PersonInfo{GivenName: "given-name", MiddleNames: []string{}, FamilyName: "family-name", SSN: "SSN",
+				DateOfBirth: parseDate(t, "1970-01-01"), Citizenship: "SE"},
+			tags: []string{"required"},
+		},
+		{
+			desc: "minor",
+			info: minor,
+			tags: []string{"min_age"},
+		},
+		{
+			desc: "organization registered abroad",
+			info: testOrg(t),
+			tags: []string{"oneof"},
+		},
+		{
+			desc: "built-in rules still apply",
+			info: &OrganizationInfo{Form: "Oy", LeagalID: "legal-id", RegistrationDate: parseDate(t, "2000-01-01"), RegistrationCountry: "FI"},
+			tags: []string{"org-name"},
+		},
+	}
+	for i := range testCases {
+		tC := testCases[i]
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := v.Struct(tC.info)
+
+			var tags []string
+			var verrs validator.ValidationErrors
+			if errors.As(err, &verrs) {
+				for _, fe := range verrs {
+					tags = append(tags, fe.Tag())
+				}
+			}
+			assert.ElementsMatch(t, tC.tags, tags, "failed rules")
+		})
+	}
+
+	assert.Nil(t, NewValidator().Struct(minor), "profile rules should not change the built-in validator")
+
+	_, err = NewProfileValidator(Profile{Name: "bad", Required: []string{"shoe_size"}}, DefaultAgeRules)
+	assert.True(t, errors.Is(err, ErrUnknownField), "required fields should exist")
+
+	assert.Equal(t, 17, Age(parseDate(t, "2000-03-02").ToTime(), parseDate(t, "2018-03-01").ToTime()), "day before birthday")
+	assert.Equal(t, 18, Age(parseDate(t, "2000-03-02").ToTime(), parseDate(t, "2018-03-02").ToTime()), "birthday")
+}
+
+func TestAgeRules(t *testing.T) {
+	t.Parallel()
+
+	born := func(years int) date.Date {
+		return date.Date{Time: time.Now().AddDate(-years, 0, 0)}
+	}
+
+	person := func(dob date.Date) *PersonInfo {
+		pi := testPerson(t)
+		pi.DateOfBirth = dob
+		return pi
+	}
+
+	org := func(registered string) *OrganizationInfo {
+		oi := testOrg(t)
+		oi.RegistrationDate = parseDate(t, registered)
+		return oi
+	}
+
+	testCases := []struct {
+		desc  string
+		rules AgeRules
+		info  Info
+		tag   string
+	}{
+		{desc: "person", rules: DefaultAgeRules, info: person(born(130))},
+		{desc: "born too long ago", rules: DefaultAgeRules, info: person(born(131)), tag: "max_age"},
+		{desc: "born in the future", rules: DefaultAgeRules, info: person(born(-1)), tag: "before"},
+		{desc: "max age disabled", rules: AgeRules{}, info: person(born(200))},
+		{desc: "organization", rules: DefaultAgeRules, info: org("1800-01-01")},
+		{desc: "registered too early", rules: DefaultAgeRules, info: org("1799-12-31"), tag: "min_registration"},
+		{desc: "earliest registration disabled", rules: AgeRules{}, info: org("1600-01-01")},
+	}
+	for i := range testCases {
+		tC := testCases[i]
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := NewValidatorWithAgeRules(tC.rules).Struct(tC.info)
+
+			if tC.tag == "" {
+				assert.Nil(t, err, "error should be nil")
+			} else {
+				var verrs validator.ValidationErrors
+				assert.True(t, errors.As(err, &verrs), "validation errors expected")
+				assert.Equal(t, tC.tag, verrs[0].Tag(), "failed rule")
+			}
+		})
+	}
+
+	assert.NotPanics(t, func() {
+		assert.NotNil(t, NewValidator().Var("1970-01-01", "before"), "strings are not dates")
+		assert.NotNil(t, NewValidator().Var(42, "max_age"), "numbers are not dates")
+	}, "date rules should be type-safe")
+
+	minor := New(1, person(born(17)))
+	assert.True(t, errors.Is(minor.SetState(Active, DefaultAgeRules), ErrTooYoung), "minors can not become active")
+	assert.Nil(t, minor.SetState(Passive, DefaultAgeRules), "minors can become passive")
+	assert.Nil(t, minor.SetState(Active, AgeRules{}), "minimum age disabled")
+
+	trader := testSoleTrader(t)
+	trader.DateOfBirth = born(17)
+	assert.True(t, errors.Is(New(1, trader).SetState(Active, DefaultAgeRules), ErrTooYoung), "sole traders are persons")
+
+	assert.Nil(t, New(1, person(born(18))).SetState(Active, DefaultAgeRules), "adults become active")
+	assert.Nil(t, New(1, testOrg(t)).SetState(Active, DefaultAgeRules), "organizations have no age")
+
+	merged := &Customer{ID: 1, State: Prospect, Info: testPerson(t), MergedInto: 2}
+	assert.True(t, errors.Is(merged.SetState(Active, DefaultAgeRules), ErrMerged), "redirects have no state")
+}
+
+func TestFieldErrors(t *testing.T) {
+	t.Parallel()
+
+	// every tag of the info types and of the registry must have a message in every language
+	tags := map[string]bool{"min": true, "max": true, "gt": true, "lte": true, "min_age": true, "oneof": true}
+	for _, i := range profileTypes {
+		typ := reflect.TypeOf(i)
+		for idx := 0; idx < typ.NumField(); idx++ {
+			for _, tag := range strings.Split(typ.Field(idx).Tag.Get("validate"), ",") {
+				if tag = strings.Split(tag, "=")[0]; tag != "" && tag != "dive" && tag != "omitempty" {
+					tags[tag] = true
+				}
+			}
+		}
+	}
+	for tag := range tags {
+		for _, lang := range Languages {
+			assert.NotEmptyf(t, messages[tag][lang], "message of %s in %s", tag, lang)
+		}
+	}
+	for key, byLang := range messages {
+		assert.Lenf(t, byLang, len(Languages), "languages of %s", key)
+	}
+
+	assert.Equal(t, language.Finnish, MatchLanguage("fi-FI, en;q=0.5"), "regional variant")
+	assert.Equal(t, language.Swedish, MatchLanguage("de, sv;q=0.8"), "first supported")
+	assert.Equal(t, language.English, MatchLanguage(""), "default")
+	assert.Equal(t, language.English, MatchLanguage(";;"), "malformed")
+
+	pi := testPerson(t)
+	pi.GivenName = "R2-D2"
+	pi.Citizenship = "Finland"
+
+	fes := FieldErrors(errors.Wrap(NewValidator().Struct(pi), "wrapped"), language.Swedish)
+	assert.Equal(t, []FieldError{
+		{Field: "given_name", Tag: "person-name", Message: messages["person-name"][language.Swedish]},
+		{Field: "citizenship", Tag: "iso3166_1_alpha2", Message: "måste vara en landskod enligt ISO 3166-1 alpha-2"},
+	}, fes, "field errors")
+
+	fes = FieldErrors(NewValidator().Var([]uint32{}, "min=1"), language.Finnish)
+	assert.Equal(t, "on oltava vähintään 1 kohdetta", fes[0].Message, "list variant")
+
+	fes = FieldErrors(NewValidator().Var("a", "min=2"), language.English)
+	assert.Equal(t, "must be at least 2 characters long", fes[0].Message, "string variant")
+
+	assert.Nil(t, FieldErrors(ErrErased, language.English), "not a validation error")
+}
@@ -0,0 +1,193 @@
+package customer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/text/language"
+)
+
+// Languages of validation messages, the first is used when no other matches
+var Languages = []language.Tag{language.English, language.Finnish, language.Swedish}
+
+var languageMatcher = language.NewMatcher(Languages)
+
+// MatchLanguage returns the language of Languages best matching an Accept-Language value
+func MatchLanguage(accept string) language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(accept)
+	if err != nil || len(tags) == 0 {
+		return Languages[0]
+	}
+
+	_, idx, _ := languageMatcher.Match(tags...)
+	return Languages[idx]
+}
+
+// FieldError is a validation failure of a single field with a message in the language asked
+// for. Field is the JSON name of the field and empty for single values.
+type FieldError struct {
+	Field   string
+	Tag     string
+	Param   string
+	Message string
+}
+
+// messages are message formats by validation tag and language, a format takes the tag
+// parameter. Tags of minimum and maximum lengths have variants for strings and lists.
+var messages = map[string]map[language.Tag]string{
+	"required": {
+		language.English: "is required",
+		language.Finnish: "on pakollinen",
+		language.Swedish: "är obligatoriskt",
+	},
+	"min": {
+		language.English: "must be at least %s",
+		language.Finnish: "on oltava vähintään %s",
+		language.Swedish: "måste vara minst %s",
+	},
+	"min.chars": {
+		language.English: "must be at least %s characters long",
+		language.Finnish: "on oltava vähintään %s merkkiä pitkä",
+		language.Swedish: "måste vara minst %s tecken lång",
+	},
+	"min.items": {
+		language.English: "must have at least %s items",
+		language.Finnish: "on oltava vähintään %s kohdetta",
+		language.Swedish: "måste ha minst %s poster",
+	},
+	"max": {
+		language.English: "must be at most %s",
+		language.Finnish: "saa olla enintään %s",
+		language.Swedish: "får vara högst %s",
+	},
+	"max.chars": {
+		language.English: "must be at most %s characters long",
+		language.Finnish: "saa olla enintään %s merkkiä pitkä",
+		language.Swedish: "får vara högst %s tecken lång",
+	},
+	"max.items": {
+		language.English: "must have at most %s items",
+		language.Finnish: "saa olla enintään %s kohdetta",
+		language.Swedish: "får ha högst %s poster",
+	},
+	"gt": {
+		language.English: "must be greater than %s",
+		language.Finnish: "on oltava suurempi kuin %s",
+		language.Swedish: "måste vara större än %s",
+	},
+	"lte": {
+		language.English: "must be at most %s",
+		language.Finnish: "saa olla enintään %s",
+		language.Swedish: "får vara högst %s",
+	},
+	"oneof": {
+		language.English: "must be one of %s",
+		language.Finnish: "on oltava jokin seuraavista: %s",
+		language.Swedish: "måste vara en av %s",
+	},
+	"iso3166_1_alpha2": {
+		language.English: "must be an ISO 3166-1 alpha-2 country code",
+		language.Finnish: "on oltava ISO 3166-1 alpha-2 -maakoodi",
+		language.Swedish: "måste vara en landskod enligt ISO 3166-1 alpha-2",
+	},
+	"person-name": {
+		language.English: "must be letters, words joined by a single space, hyphen or apostrophe",
+		language.Finnish: "saa sisältää vain kirjaimia, sanat erotettuina yhdellä välilyönnillä, yhdysmerkillä tai heittomerkillä",
+		language.Swedish: "får bara innehålla bokstäver, ord åtskilda av ett mellanslag, bindestreck eller en apostrof",
+	},
+	"org-name": {
+		language.English: "must be letters and digits, joined by spaces, hyphens or ampersands",
+		language.Finnish: "saa sisältää vain kirjaimia ja numeroita sekä niiden välissä välilyöntejä, yhdysmerkkejä tai et-merkkejä",
+		language.Swedish: "får bara innehålla bokstäver och siffror samt mellanslag, bindestreck eller et-tecken mellan dem",
+	},
+	"before": {
+		language.English: "must be in the past",
+		language.Finnish: "on oltava menneisyydessä",
+		language.Swedish: "måste vara i det förflutna",
+	},
+	"max_age": {
+		language.English: "is too far in the past",
+		language.Finnish: "on liian kaukana menneisyydessä",
+		language.Swedish: "ligger för långt bak i tiden",
+	},
+	"min_registration": {
+		language.English: "is before the earliest accepted registration date",
+		language.Finnish: "on ennen aikaisinta hyväksyttyä rekisteröintipäivää",
+		language.Swedish: "är före det tidigaste godkända registreringsdatumet",
+	},
+	"min_age": {
+		language.English: "must give an age of at least %s years",
+		language.Finnish: "iän on oltava vähintään %s vuotta",
+		language.Swedish: "måste ge en ålder på minst %s år",
+	},
+}
+
+// invalid is the message of tags without a message of their own
+var invalid = map[language.Tag]string{
+	language.English: "is invalid",
+	language.Finnish: "on virheellinen",
+	language.Swedish: "är ogiltigt",
+}
+
+var validationFailed = map[language.Tag]string{
+	language.English: "Input validation failed",
+	language.Finnish: "Syötteen tarkistus epäonnistui",
+	language.Swedish: "Validering av indata misslyckades",
+}
+
+// ValidationFailed returns the summary of validation failures in language lang
+func ValidationFailed(lang language.Tag) string {
+	if s, ok := validationFailed[lang]; ok {
+		return s
+	}
+	return validationFailed[Languages[0]]
+}
+
+// FieldErrors returns the validation failures in err with messages in language lang, nil
+// when err holds none. Languages other than those of Languages fall back to the first.
+func FieldErrors(err error, lang language.Tag) []FieldError {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return nil
+	}
+
+	if _, ok := validationFailed[lang]; !ok {
+		lang = Languages[0]
+	}
+
+	fes := make([]FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		fes = append(fes, FieldError{
+			Field:   fe.Field(),
+			Tag:     fe.Tag(),
+			Param:   fe.Param(),
+			Message: message(fe, lang),
+		})
+	}
+	return fes
+}
+
+func message(fe validator.FieldError, lang language.Tag) string {
+	key := fe.Tag()
+	if key == "min" || key == "max" {
+		switch fe.Kind() {
+		case reflect.String:
+			key += ".chars"
+		case reflect.Slice, reflect.Map, reflect.Array:
+			key += ".items"
+		}
+	}
+
+	format, ok := messages[key][lang]
+	if !ok {
+		return invalid[lang]
+	}
+
+	if !strings.Contains(format, "%s") {
+		return format
+	}
+	return fmt.Sprintf(format, fe.Param())
+}
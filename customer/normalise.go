@@ -0,0 +1,139 @@
+package customer
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+var ErrUnknownCasing = errors.New("Unknown casing")
+
+// Casing selects how Normalise cases names, identifiers and codes are never recased
+type Casing int
+
+const (
+	// KeepCase keeps names as entered
+	KeepCase Casing = iota
+	// TitleCase upper cases the first letter of every word and lower cases the rest
+	TitleCase
+	// UpperCase upper cases names
+	UpperCase
+)
+
+// ParseCasing returns the casing named keep, title or upper
+func ParseCasing(s string) (Casing, error) {
+	switch s {
+	case "keep", "":
+		return KeepCase, nil
+	case "title":
+		return TitleCase, nil
+	case "upper":
+		return UpperCase, nil
+	}
+	return KeepCase, errors.Wrapf(ErrUnknownCasing, "%q", s)
+}
+
+func (c Casing) apply(s string) string {
+	switch c {
+	case TitleCase:
+		return cases.Title(language.Und).String(s)
+	case UpperCase:
+		return cases.Upper(language.Und).String(s)
+	}
+	return s
+}
+
+// particle cases a family name prefix, title casing keeps prefixes such as van der and de la
+// as entered since their case differs by country
+func (c Casing) particle(s string) string {
+	if c == TitleCase {
+		return s
+	}
+	return c.apply(s)
+}
+
+// Display returns the display form of s: Unicode NFC with white space trimmed and runs of
+// white space collapsed to a single space
+func Display(s string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(s)), " ")
+}
+
+// Canonical returns the form of a name compared by search, the display form case folded
+func Canonical(s string) string {
+	return cases.Fold().String(Display(s))
+}
+
+// CanonicalID returns the form of an SSN or legal ID compared for uniqueness, NFC without
+// white space and upper cased
+func CanonicalID(s string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(norm.NFC.String(s)), ""))
+}
+
+// Normalise brings the info to its display form in place, names cased by c
+func (pi *PersonInfo) Normalise(c Casing) {
+	if pi == nil {
+		return
+	}
+	pi.GivenName = c.apply(Display(pi.GivenName))
+	pi.MiddleNames = displayAll(pi.MiddleNames, c)
+	pi.FamilyNamePrefix = c.particle(Display(pi.FamilyNamePrefix))
+	pi.FamilyName = c.apply(Display(pi.FamilyName))
+	pi.SSN = Display(pi.SSN)
+	pi.Citizenship = Display(pi.Citizenship)
+}
+
+// CanonicalName is the full name compared by search
+func (pi *PersonInfo) CanonicalName() string {
+	return Canonical(pi.FullName())
+}
+
+// displayAll returns the display forms of names cased by c, blank names dropped
+func displayAll(names []string, c Casing) []string {
+	var kept []string
+	for _, n := range names {
+		if n = c.apply(Display(n)); n != "" {
+			kept = append(kept, n)
+		}
+	}
+	return kept
+}
+
+// Normalise brings the info to its display form in place, the name cased by c
+func (oi *OrganizationInfo) Normalise(c Casing) {
+	if oi == nil {
+		return
+	}
+	oi.Name = c.apply(Display(oi.Name))
+	oi.Form = Display(oi.Form)
+	oi.LeagalID = Display(oi.LeagalID)
+	oi.RegistrationCountry = Display(oi.RegistrationCountry)
+}
+
+// CanonicalName is the name compared by search
+func (oi *OrganizationInfo) CanonicalName() string {
+	return Canonical(oi.Name)
+}
+
+// Normalise brings the info to its display form in place, names cased by c
+func (si *SoleTraderInfo) Normalise(c Casing) {
+	if si == nil {
+		return
+	}
+	si.GivenName = c.apply(Display(si.GivenName))
+	si.MiddleNames = displayAll(si.MiddleNames, c)
+	si.FamilyNamePrefix = c.particle(Display(si.FamilyNamePrefix))
+	si.FamilyName = c.apply(Display(si.FamilyName))
+	si.SSN = Display(si.SSN)
+	si.Citizenship = Display(si.Citizenship)
+	si.TradeName = c.apply(Display(si.TradeName))
+	si.BusinessID = Display(si.BusinessID)
+}
+
+// CanonicalName is the trade name followed by the full name of the person, both are
+// compared by search
+func (si *SoleTraderInfo) CanonicalName() string {
+	return Canonical(si.TradeName + " " + si.Person().FullName())
+}
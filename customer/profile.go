@@ -0,0 +1,142 @@
+package customer
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+// Profile is a named and versioned set of validation rules a deployment adds to the built-in
+// rules, for example for a tenant or a country with stricter requirements
+type Profile struct {
+	Name    string
+	Version uint32
+	// Required names info fields by their JSON names that must not be empty, a field applies
+	// to the info types that have it
+	Required []string
+	// MinAge is the minimum age in years of persons and sole traders, zero for none
+	MinAge int
+	// Citizenships allowed for persons and sole traders, any when empty
+	Citizenships []string
+	// RegistrationCountries allowed for organizations, any when empty
+	RegistrationCountries []string
+}
+
+// ProfileRef names the profile version info was last validated against, zero for the
+// built-in rules only
+type ProfileRef struct {
+	Name    string
+	Version uint32
+}
+
+func (p Profile) Ref() ProfileRef {
+	return ProfileRef{Name: p.Name, Version: p.Version}
+}
+
+// profileTypes are the info types profiles apply to
+var profileTypes = []interface{}{PersonInfo{}, OrganizationInfo{}, SoleTraderInfo{}}
+
+// NewProfileValidator compiles the rules of p into a validator that also applies the
+// built-in rules with age rules r. Required fields that no info type has are rejected.
+func NewProfileValidator(p Profile, r AgeRules) (*validator.Validate, error) {
+	const op string = "customer.NewProfileValidator"
+
+	required := map[reflect.Type][]int{}
+
+	for _, name := range p.Required {
+		found := false
+		for _, i := range profileTypes {
+			t := reflect.TypeOf(i)
+			if f, ok := fieldIndex(t, name); ok {
+				required[t] = append(required[t], f)
+				found = true
+			}
+		}
+		if !found {
+			return nil, errors.Wrapf(ErrUnknownField, "%s: profile %s: %q", op, p.Name, name)
+		}
+	}
+
+	v := NewValidatorWithAgeRules(r)
+	v.RegisterStructValidation(func(sl validator.StructLevel) {
+		p.validate(sl, required[sl.Current().Type()])
+	}, profileTypes...)
+
+	return v, nil
+}
+
+func (p Profile) validate(sl validator.StructLevel, required []int) {
+	s := sl.Current()
+
+	for _, f := range required {
+		if missing(s.Field(f)) {
+			reportField(sl, f, "required", "")
+		}
+	}
+
+	var (
+		dob       date.Date
+		country   string
+		field     string
+		countries []string
+	)
+
+	switch i := s.Interface().(type) {
+	case PersonInfo:
+		dob, country, countries = i.DateOfBirth, i.Citizenship, p.Citizenships
+		field = "Citizenship"
+	case SoleTraderInfo:
+		dob, country, countries = i.DateOfBirth, i.Citizenship, p.Citizenships
+		field = "Citizenship"
+	case OrganizationInfo:
+		country, countries = i.RegistrationCountry, p.RegistrationCountries
+		field = "RegistrationCountry"
+	}
+
+	if p.MinAge > 0 && !dob.IsZero() && Age(dob.ToTime(), time.Now()) < p.MinAge {
+		f, _ := s.Type().FieldByName("DateOfBirth")
+		reportField(sl, f.Index[0], "min_age", strconv.Itoa(p.MinAge))
+	}
+
+	if len(countries) > 0 && country != "" && !containsFold(countries, country) {
+		f, _ := s.Type().FieldByName(field)
+		reportField(sl, f.Index[0], "oneof", strings.Join(countries, " "))
+	}
+}
+
+// Age returns the age in completed years at now of someone born at birth
+func Age(birth, now time.Time) int {
+	age := now.Year() - birth.Year()
+	if now.Month() < birth.Month() || (now.Month() == birth.Month() && now.Day() < birth.Day()) {
+		age--
+	}
+	return age
+}
+
+// missing reports whether a required field is unset, slices and maps must not be empty
+func missing(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+func reportField(sl validator.StructLevel, index int, tag, param string) {
+	f := sl.Current().Type().Field(index)
+	sl.ReportError(sl.Current().Field(index).Interface(), fieldName(f), f.Name, tag, param)
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
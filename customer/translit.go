@@ -0,0 +1,61 @@
+package customer
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// stripMarks decomposes letters and drops their combining marks, ä becomes a
+var stripMarks = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// latin holds the Latin spelling of letters that do not decompose into a base letter and
+// marks, and of the Cyrillic and Greek alphabets. Scripts missing here are left as written.
+var latin = map[rune]string{
+	// Latin
+	'æ': "ae", 'ø': "o", 'œ': "oe", 'đ': "d", 'ð': "d", 'þ': "th", 'ł': "l", 'ı': "i", 'ħ': "h",
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'ґ': "g", 'д': "d", 'е': "e", 'є': "ie", 'ж': "zh",
+	'з': "z", 'и': "i", 'і': "i", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p",
+	'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh",
+	'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "iu", 'я': "ia",
+	// Greek
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i",
+	'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s",
+	'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// Transliterate spells a canonical name in unaccented Latin letters where it can, so that
+// Müller is found by muller and Иванов by ivanov. Apostrophes are dropped, O'Brien and
+// OBrien spell the same.
+func Transliterate(canonical string) string {
+	s, _, err := transform.String(stripMarks, canonical)
+	if err != nil {
+		s = canonical
+	}
+
+	// the Greek digraph ου is spelled ou, not oy
+	s = strings.ReplaceAll(s, "ου", "ou")
+
+	var b strings.Builder
+	for _, r := range s {
+		if l, ok := latin[r]; ok {
+			b.WriteString(l)
+			continue
+		}
+		if r == '\'' || r == '’' {
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// SearchKey returns the form of a name or query compared by search
+func SearchKey(s string) string {
+	return Transliterate(Canonical(s))
+}
@@ -3,26 +3,88 @@ package customer
 import (
 	"reflect"
 	"regexp"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/go-playground/validator/v10"
 )
 
+// AgeRules bound dates of birth and registration and set the age at which persons and sole
+// traders may become Active, zero values disable a rule
+type AgeRules struct {
+	// MinActiveAge is the minimum age in years to become Active
+	MinActiveAge int
+	// MaxAge is the highest age in years a date of birth may give
+	MaxAge int
+	// EarliestRegistration is the earliest registration date of organizations
+	EarliestRegistration time.Time
+}
+
+var DefaultAgeRules = AgeRules{
+	MinActiveAge:         18,
+	MaxAge:               130,
+	EarliestRegistration: time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC),
+}
+
+// NewValidator returns a validator of the built-in rules with DefaultAgeRules
 func NewValidator() *validator.Validate {
+	return NewValidatorWithAgeRules(DefaultAgeRules)
+}
+
+func NewValidatorWithAgeRules(r AgeRules) *validator.Validate {
 	v := validator.New()
+	v.RegisterTagNameFunc(fieldName)
 	v.RegisterValidation("person-name", ValidatePersonName)
 	v.RegisterValidation("org-name", ValidateOrgName)
 	v.RegisterValidation("before", ValidateBeforeNow)
+	v.RegisterValidation("max_age", r.validateMaxAge)
+	v.RegisterValidation("min_registration", r.validateRegistration)
 	v.RegisterCustomTypeFunc(ValidateDate, date.Date{})
 	return v
 }
 
-// single unicode Letter or 2 to many unicode Letters, allowing - in the middle
-var personNameRegexp *regexp.Regexp = regexp.MustCompile(`^([\p{L}])$|^([\p{L}])([\p{L}-])*([\p{L}])$`)
+// fieldName names fields in validation errors by their JSON names
+func fieldName(f reflect.StructField) string {
+	if name := strings.Split(f.Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+		return name
+	}
+	return f.Name
+}
+
+// nameSeparators join the words of a person name, as in Anne Marie, Smith-Jones and O'Brien
+const nameSeparators = " -'’"
 
+// ValidatePersonName accepts words of letters of any script joined by single separators.
+// Letters may carry combining marks, so names written in decomposed form are valid too.
 func ValidatePersonName(fl validator.FieldLevel) bool {
-	return personNameRegexp.MatchString(fl.Field().String())
+	return isPersonName(fl.Field().String())
+}
+
+func isPersonName(s string) bool {
+	// letter reports whether the previous rune was a letter or a mark on one
+	letter := false
+
+	for _, r := range s {
+		switch {
+		case unicode.IsLetter(r):
+			letter = true
+		case unicode.Is(unicode.M, r):
+			if !letter {
+				return false
+			}
+		case strings.ContainsRune(nameSeparators, r):
+			if !letter {
+				return false
+			}
+			letter = false
+		default:
+			return false
+		}
+	}
+
+	return letter
 }
 
 // single unicode Letter,digit OR 2 to many unicode Letters, digits, allowing - & and space in the middle
@@ -32,9 +94,36 @@ func ValidateOrgName(fl validator.FieldLevel) bool {
 	return orgNameRegexp.MatchString(fl.Field().String())
 }
 
+// ValidateBeforeNow accepts times and dates in the past, fields of other types are invalid
 func ValidateBeforeNow(fl validator.FieldLevel) bool {
+	t, ok := timeOf(fl.Field())
+	return ok && t.Before(time.Now())
+}
+
+func (r AgeRules) validateMaxAge(fl validator.FieldLevel) bool {
+	t, ok := timeOf(fl.Field())
+	return ok && (r.MaxAge <= 0 || Age(t, time.Now()) <= r.MaxAge)
+}
+
+func (r AgeRules) validateRegistration(fl validator.FieldLevel) bool {
+	t, ok := timeOf(fl.Field())
+	return ok && !t.Before(r.EarliestRegistration)
+}
+
+// timeOf returns the time of time and date fields
+func timeOf(field reflect.Value) (time.Time, bool) {
+	if !field.IsValid() || !field.CanInterface() {
+		return time.Time{}, false
+	}
+
+	switch t := field.Interface().(type) {
+	case time.Time:
+		return t, true
+	case date.Date:
+		return t.ToTime(), true
+	}
 
-	return fl.Field().Interface().(time.Time).Before(time.Now())
+	return time.Time{}, false
 }
 
 func ValidateDate(field reflect.Value) interface{} {
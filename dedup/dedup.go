@@ -0,0 +1,354 @@
+// Package dedup finds probable duplicate customers. Customers are grouped by blocking keys
+// and only customers sharing a key are compared, so the cost grows with the block sizes
+// rather than with the square of the number of customers.
+package dedup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/registry"
+)
+
+// DefaultMaxBlockSize is the largest block compared, larger blocks are too common a key to
+// tell duplicates apart
+const DefaultMaxBlockSize = 1000
+
+// weights of the compared attributes, a pair matching on all of them scores 1
+const (
+	nameWeight    = 0.6
+	dateWeight    = 0.3
+	yearWeight    = 0.1
+	countryWeight = 0.1
+)
+
+type Option func(*Engine)
+
+// WithMaxBlockSize sets the largest block compared
+func WithMaxBlockSize(n int) Option {
+	return func(e *Engine) {
+		e.maxBlock = n
+	}
+}
+
+func NewEngine(opts ...Option) *Engine {
+	e := &Engine{maxBlock: DefaultMaxBlockSize}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Engine scores pairs by normalised name similarity, date of birth or registration and
+// citizenship or registration country. Pairs sharing an SSN or legal ID, however written,
+// score 1.
+type Engine struct {
+	maxBlock int
+}
+
+// profile is the normalised form of the compared attributes of a customer
+type profile struct {
+	id      uint32
+	kind    string
+	names   []string
+	date    string
+	country string
+	ident   string
+	keys    []string
+}
+
+// Duplicates returns the pairs of cs scoring at least minScore, highest score first
+func (e *Engine) Duplicates(ctx context.Context, cs []*customer.Customer, minScore float64) ([]registry.Duplicate, error) {
+	blocks := map[string][]*profile{}
+
+	for _, c := range cs {
+		p, ok := newProfile(c)
+		if !ok {
+			continue
+		}
+		for _, k := range p.keys {
+			blocks[k] = append(blocks[k], p)
+		}
+	}
+
+	type pair struct{ a, b uint32 }
+
+	seen := map[pair]bool{}
+
+	var found []registry.Duplicate
+
+	for _, block := range blocks {
+		if len(block) > e.maxBlock {
+			continue
+		}
+
+		for i, a := range block {
+			for _, b := range block[i+1:] {
+				lo, hi := a, b
+				if lo.id > hi.id {
+					lo, hi = hi, lo
+				}
+
+				k := pair{lo.id, hi.id}
+				if lo.id == hi.id || seen[k] {
+					continue
+				}
+				seen[k] = true
+
+				if d := score(lo, hi); d.Score >= minScore {
+					found = append(found, d)
+				}
+			}
+
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].Score != found[j].Score {
+			return found[i].Score > found[j].Score
+		}
+		if found[i].ID != found[j].ID {
+			return found[i].ID < found[j].ID
+		}
+		return found[i].OtherID < found[j].OtherID
+	})
+
+	return found, nil
+}
+
+func newProfile(c *customer.Customer) (*profile, bool) {
+	if c.Erased || c.MergedInto != 0 {
+		return nil, false
+	}
+
+	var p *profile
+
+	switch i := c.Info.(type) {
+	case *customer.PersonInfo:
+		p = personProfile(c.ID, "person", i, i.SSN)
+	case *customer.SoleTraderInfo:
+		p = personProfile(c.ID, "sole-trader", i.Person(), i.BusinessID)
+	case *customer.OrganizationInfo:
+		name := withoutForm(normalise(i.Name), normalise(i.Form))
+		p = &profile{
+			id:      c.ID,
+			kind:    "org",
+			names:   []string{join(name)},
+			date:    i.RegistrationDate.String(),
+			country: strings.ToUpper(i.RegistrationCountry),
+			ident:   ident(i.LeagalID),
+		}
+		p.keys = []string{"registered:" + p.date}
+		if len(name) > 0 {
+			p.keys = append(p.keys, "name:"+prefix(name[0], 4))
+		}
+	default:
+		return nil, false
+	}
+
+	if p.ident != "" {
+		p.keys = append(p.keys, "ident:"+p.ident)
+	}
+
+	// customers of different kinds never share a block
+	for i := range p.keys {
+		p.keys[i] = p.kind + ":" + p.keys[i]
+	}
+
+	return p, true
+}
+
+// ident returns the canonical form of an SSN or legal ID without hyphens, which are often
+// left out when identifiers are entered
+func ident(s string) string {
+	return strings.ReplaceAll(customer.CanonicalID(s), "-", "")
+}
+
+func personProfile(id uint32, kind string, i *customer.PersonInfo, identifier string) *profile {
+	given, family := normalise(i.GivenName), normalise(i.FamilyNamePrefix+" "+i.FamilyName)
+	p := &profile{
+		id:   id,
+		kind: kind,
+		// swapped given and family names are a common data entry error
+		names:   []string{join(given, family), join(family, given)},
+		date:    i.DateOfBirth.String(),
+		country: strings.ToUpper(i.Citizenship),
+		ident:   ident(identifier),
+	}
+	p.keys = []string{"dob:" + p.date}
+	if len(family) > 0 {
+		p.keys = append(p.keys, "family:"+prefix(family[0], 4)+":"+year(p.date))
+	}
+	return p
+}
+
+func score(a, b *profile) registry.Duplicate {
+	d := registry.Duplicate{ID: a.id, OtherID: b.id}
+
+	if a.ident != "" && a.ident == b.ident {
+		d.Score = 1
+		d.Reasons = append(d.Reasons, "same identifier")
+		return d
+	}
+
+	var sim float64
+	for _, n := range b.names {
+		if s := jaroWinkler(a.names[0], n); s > sim {
+			sim = s
+		}
+	}
+	d.Score += nameWeight * sim
+	d.Reasons = append(d.Reasons, fmt.Sprintf("name similarity %.2f", sim))
+
+	date := "date of birth"
+	if a.kind == "org" {
+		date = "registration date"
+	}
+
+	switch {
+	case a.date == b.date:
+		d.Score += dateWeight
+		d.Reasons = append(d.Reasons, "same "+date)
+	case year(a.date) == year(b.date):
+		d.Score += yearWeight
+		d.Reasons = append(d.Reasons, "same year of "+date)
+	}
+
+	if a.country != "" && a.country == b.country {
+		d.Score += countryWeight
+		d.Reasons = append(d.Reasons, "same country")
+	}
+
+	// rounds away floating point error so that a full match scores exactly 1
+	d.Score = float64(int(d.Score*1000+0.5)) / 1000
+
+	return d
+}
+
+// normalise transliterates the canonical form of s and splits it into words of letters and
+// digits
+func normalise(s string) []string {
+	return strings.FieldsFunc(customer.SearchKey(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// withoutForm drops the words of the legal form from an organization name
+func withoutForm(name, form []string) []string {
+	drop := map[string]bool{}
+	for _, w := range form {
+		drop[w] = true
+	}
+
+	kept := make([]string, 0, len(name))
+	for _, w := range name {
+		if !drop[w] {
+			kept = append(kept, w)
+		}
+	}
+	return kept
+}
+
+func join(parts ...[]string) string {
+	var words []string
+	for _, p := range parts {
+		words = append(words, p...)
+	}
+	return strings.Join(words, " ")
+}
+
+func prefix(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
+
+func year(date string) string {
+	return prefix(date, 4)
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of a and b between 0 and 1
+func jaroWinkler(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := max(len(ra), len(rb))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	ma, mb := make([]bool, len(ra)), make([]bool, len(rb))
+
+	var matches int
+	for i := range ra {
+		lo, hi := max(0, i-window), min(len(rb), i+window+1)
+		for j := lo; j < hi; j++ {
+			if !mb[j] && ra[i] == rb[j] {
+				ma[i], mb[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+
+	if matches == 0 {
+		return 0
+	}
+
+	var transpositions, j int
+	for i := range ra {
+		if !ma[i] {
+			continue
+		}
+		for !mb[j] {
+			j++
+		}
+		if ra[i] != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	var common int
+	for common < min(4, min(len(ra), len(rb))) && ra[common] == rb[common] {
+		common++
+	}
+
+	return jaro + float64(common)*0.1*(1-jaro)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,181 @@
+package dedup_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/dedup"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	testCases := []struct {
+		desc  string
+		opts  []dedup.Option
+		a, b  customer.Info
+		erase bool
+		match bool
+		score float64
+	}{
+		{
+			desc:  "identical",
+			a:     testPerson(t, "Anna", "Virtanen", "010170-123A", "1970-01-01"),
+			b:     testPerson(t, "Anna", "Virtanen", "010170-123B", "1970-01-01"),
+			match: true,
+			score: 1,
+		},
+		{
+			desc:  "typo in name",
+			a:     testPerson(t, "Anna", "Virtanen", "010170-123A", "1970-01-01"),
+			b:     testPerson(t, "Ana", "Virtannen", "010170-123B", "1970-01-01"),
+			match: true,
+		},
+		{
+			desc:  "swapped names",
+			a:     testPerson(t, "Anna", "Virtanen", "010170-123A", "1970-01-01"),
+			b:     testPerson(t, "Virtanen", "Anna", "010170-123B", "1970-01-01"),
+			match: true,
+			score: 1,
+		},
+		{
+			desc:  "case and punctuation",
+			a:     testPerson(t, "Anna-Liisa", "Virtanen", "010170-123A", "1970-01-01"),
+			b:     testPerson(t, "ANNA LIISA", "virtanen", "010170-123B", "1970-01-01"),
+			match: true,
+			score: 1,
+		},
+		{
+			desc:  "accents and apostrophes",
+			a:     testPerson(t, "Séamus", "O'Brien", "010170-123A", "1970-01-01"),
+			b:     testPerson(t, "Seamus", "OBrien", "010170-123B", "1970-01-01"),
+			match: true,
+			score: 1,
+		},
+		{
+			desc: "same birthday",
+			a:    testPerson(t, "Anna", "Virtanen", "010170-123A", "1970-01-01"),
+			b:    testPerson(t, "Pekka", "Korhonen", "010170-123B", "1970-01-01"),
+		},
+		{
+			desc:  "same SSN",
+			a:     testPerson(t, "Anna", "Virtanen", "010170-123A", "1970-01-01"),
+			b:     testPerson(t, "Anna", "Nieminen", "010170-123A", "1970-01-01"),
+			match: true,
+			score: 1,
+		},
+		{
+			desc:  "SSN written differently",
+			a:     testPerson(t, "Anna", "Virtanen", "010170-123A", "1970-01-01"),
+			b:     testPerson(t, "Pekka", "Korhonen", "010170123a", "1971-02-02"),
+			match: true,
+			score: 1,
+		},
+		{
+			desc:  "legal ID written differently",
+			a:     testOrg(t, "Acme Oy", "Oy", "1234567-8", "2000-01-01"),
+			b:     testOrg(t, "Widgets Ltd", "Ltd", "12345678", "2001-02-02"),
+			match: true,
+			score: 1,
+		},
+		{
+			desc:  "organization with legal form in name",
+			a:     testOrg(t, "Acme Oy", "Oy", "1234567-8", "2000-01-01"),
+			b:     testOrg(t, "ACME", "Oy", "1234567-9", "2000-01-01"),
+			match: true,
+			score: 1,
+		},
+		{
+			desc: "person and organization",
+			a:    testPerson(t, "Acme", "Oy", "010170-123A", "2000-01-01"),
+			b:    testOrg(t, "Acme Oy", "Oy", "1234567-8", "2000-01-01"),
+		},
+		{
+			desc:  "erased",
+			a:     testPerson(t, "Anna", "Virtanen", "010170-123A", "1970-01-01"),
+			b:     testPerson(t, "Anna", "Virtanen", "010170-123B", "1970-01-01"),
+			erase: true,
+		},
+		{
+			desc: "block too large",
+			opts: []dedup.Option{dedup.WithMaxBlockSize(1)},
+			a:    testPerson(t, "Anna", "Virtanen", "010170-123A", "1970-01-01"),
+			b:    testPerson(t, "Anna", "Virtanen", "010170-123B", "1970-01-01"),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			a, b := customer.New(2, tC.a), customer.New(1, tC.b)
+			if tC.erase {
+				assert.Nil(t, b.Erase(), "error should be nil")
+			}
+
+			ds, err := dedup.NewEngine(tC.opts...).Duplicates(ctx, []*customer.Customer{a, b}, 0.8)
+			assert.Nil(t, err, "error should be nil")
+
+			if !tC.match {
+				assert.Empty(t, ds, "no duplicates expected")
+				return
+			}
+
+			assert.Len(t, ds, 1, "one pair expected")
+			assert.Equal(t, uint32(1), ds[0].ID, "lower ID first")
+			assert.Equal(t, uint32(2), ds[0].OtherID, "higher ID second")
+			assert.NotEmpty(t, ds[0].Reasons, "score should be explained")
+			if tC.score != 0 {
+				assert.Equal(t, tC.score, ds[0].Score, "score")
+			}
+		})
+	}
+}
+
+func TestDuplicatesRanked(t *testing.T) {
+	t.Parallel()
+
+	cs := []*customer.Customer{
+		customer.New(1, testPerson(t, "Anna", "Virtanen", "010170-123A", "1970-01-01")),
+		customer.New(2, testPerson(t, "Ana", "Virtanen", "010170-123B", "1970-01-01")),
+		customer.New(3, testPerson(t, "Anna", "Virtanen", "010170-123C", "1970-01-01")),
+		customer.New(4, testPerson(t, "Pekka", "Korhonen", "010170-123D", "1970-01-01")),
+	}
+
+	ds, err := dedup.NewEngine().Duplicates(context.Background(), cs, 0.8)
+	assert.Nil(t, err, "error should be nil")
+	assert.Len(t, ds, 3, "every pair of the three Annas")
+	assert.Equal(t, [2]uint32{1, 3}, [2]uint32{ds[0].ID, ds[0].OtherID}, "exact match ranks first")
+
+	for i := 1; i < len(ds); i++ {
+		assert.GreaterOrEqual(t, ds[i-1].Score, ds[i].Score, "ranked by score")
+	}
+}
+
+func testPerson(t *testing.T, given, family, ssn, dob string) *customer.PersonInfo {
+	return &customer.PersonInfo{
+		GivenName:   given,
+		FamilyName:  family,
+		SSN:         ssn,
+		DateOfBirth: parseDate(t, dob),
+		Citizenship: "FI"}
+}
+
+func testOrg(t *testing.T, name, form, legalID, registered string) *customer.OrganizationInfo {
+	return &customer.OrganizationInfo{
+		Name:                name,
+		Form:                form,
+		LeagalID:            legalID,
+		RegistrationDate:    parseDate(t, registered),
+		RegistrationCountry: "FI"}
+}
+
+func parseDate(t *testing.T, datestr string) date.Date {
+	d, err := date.ParseDate(datestr)
+	if err != nil {
+		t.Fatalf("Failed to parse date from: %s, error: %v", datestr, err)
+	}
+	return d
+}
@@ -20,8 +20,20 @@ const _ = grpc.SupportPackageIsVersion7
 type CustomerRegistryClient interface {
 	New(ctx context.Context, in *NewRequest, opts ...grpc.CallOption) (*NewResponse, error)
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
-	UpdateInfo(ctx context.Context, in *UpdateInfoRequest, opts ...grpc.CallOption) (*UpdateInfoRequest, error)
+	UpdateInfo(ctx context.Context, in *UpdateInfoRequest, opts ...grpc.CallOption) (*UpdateInfoResponse, error)
 	SetState(ctx context.Context, in *SetStateRequest, opts ...grpc.CallOption) (*SetStateResponse, error)
+	Erase(ctx context.Context, in *EraseRequest, opts ...grpc.CallOption) (*EraseResponse, error)
+	SubjectAccessReport(ctx context.Context, in *SubjectAccessReportRequest, opts ...grpc.CallOption) (*SubjectAccessReportResponse, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	BatchGet(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*BatchGetResponse, error)
+	BatchSetState(ctx context.Context, in *BatchSetStateRequest, opts ...grpc.CallOption) (*BatchSetStateResponse, error)
+	GetAsOf(ctx context.Context, in *GetAsOfRequest, opts ...grpc.CallOption) (*GetAsOfResponse, error)
+	CorrectInfo(ctx context.Context, in *CorrectInfoRequest, opts ...grpc.CallOption) (*CorrectInfoResponse, error)
+	Merge(ctx context.Context, in *MergeRequest, opts ...grpc.CallOption) (*MergeResponse, error)
+	Unmerge(ctx context.Context, in *UnmergeRequest, opts ...grpc.CallOption) (*UnmergeResponse, error)
+	FindDuplicates(ctx context.Context, in *FindDuplicatesRequest, opts ...grpc.CallOption) (*FindDuplicatesResponse, error)
+	ConvertType(ctx context.Context, in *ConvertTypeRequest, opts ...grpc.CallOption) (*ConvertTypeResponse, error)
 }
 
 type customerRegistryClient struct {
@@ -50,8 +62,8 @@ func (c *customerRegistryClient) Get(ctx context.Context, in *GetRequest, opts .
 	return out, nil
 }
 
-func (c *customerRegistryClient) UpdateInfo(ctx context.Context, in *UpdateInfoRequest, opts ...grpc.CallOption) (*UpdateInfoRequest, error) {
-	out := new(UpdateInfoRequest)
+func (c *customerRegistryClient) UpdateInfo(ctx context.Context, in *UpdateInfoRequest, opts ...grpc.CallOption) (*UpdateInfoResponse, error) {
+	out := new(UpdateInfoResponse)
 	err := c.cc.Invoke(ctx, "/CustomerRegistry/UpdateInfo", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -68,14 +80,134 @@ func (c *customerRegistryClient) SetState(ctx context.Context, in *SetStateReque
 	return out, nil
 }
 
+func (c *customerRegistryClient) Erase(ctx context.Context, in *EraseRequest, opts ...grpc.CallOption) (*EraseResponse, error) {
+	out := new(EraseResponse)
+	err := c.cc.Invoke(ctx, "/CustomerRegistry/Erase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerRegistryClient) SubjectAccessReport(ctx context.Context, in *SubjectAccessReportRequest, opts ...grpc.CallOption) (*SubjectAccessReportResponse, error) {
+	out := new(SubjectAccessReportResponse)
+	err := c.cc.Invoke(ctx, "/CustomerRegistry/SubjectAccessReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerRegistryClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, "/CustomerRegistry/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerRegistryClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, "/CustomerRegistry/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerRegistryClient) BatchGet(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*BatchGetResponse, error) {
+	out := new(BatchGetResponse)
+	err := c.cc.Invoke(ctx, "/CustomerRegistry/BatchGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerRegistryClient) BatchSetState(ctx context.Context, in *BatchSetStateRequest, opts ...grpc.CallOption) (*BatchSetStateResponse, error) {
+	out := new(BatchSetStateResponse)
+	err := c.cc.Invoke(ctx, "/CustomerRegistry/BatchSetState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerRegistryClient) GetAsOf(ctx context.Context, in *GetAsOfRequest, opts ...grpc.CallOption) (*GetAsOfResponse, error) {
+	out := new(GetAsOfResponse)
+	err := c.cc.Invoke(ctx, "/CustomerRegistry/GetAsOf", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerRegistryClient) CorrectInfo(ctx context.Context, in *CorrectInfoRequest, opts ...grpc.CallOption) (*CorrectInfoResponse, error) {
+	out := new(CorrectInfoResponse)
+	err := c.cc.Invoke(ctx, "/CustomerRegistry/CorrectInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerRegistryClient) Merge(ctx context.Context, in *MergeRequest, opts ...grpc.CallOption) (*MergeResponse, error) {
+	out := new(MergeResponse)
+	err := c.cc.Invoke(ctx, "/CustomerRegistry/Merge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerRegistryClient) Unmerge(ctx context.Context, in *UnmergeRequest, opts ...grpc.CallOption) (*UnmergeResponse, error) {
+	out := new(UnmergeResponse)
+	err := c.cc.Invoke(ctx, "/CustomerRegistry/Unmerge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerRegistryClient) FindDuplicates(ctx context.Context, in *FindDuplicatesRequest, opts ...grpc.CallOption) (*FindDuplicatesResponse, error) {
+	out := new(FindDuplicatesResponse)
+	err := c.cc.Invoke(ctx, "/CustomerRegistry/FindDuplicates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerRegistryClient) ConvertType(ctx context.Context, in *ConvertTypeRequest, opts ...grpc.CallOption) (*ConvertTypeResponse, error) {
+	out := new(ConvertTypeResponse)
+	err := c.cc.Invoke(ctx, "/CustomerRegistry/ConvertType", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CustomerRegistryServer is the server API for CustomerRegistry service.
 // All implementations must embed UnimplementedCustomerRegistryServer
 // for forward compatibility
 type CustomerRegistryServer interface {
 	New(context.Context, *NewRequest) (*NewResponse, error)
 	Get(context.Context, *GetRequest) (*GetResponse, error)
-	UpdateInfo(context.Context, *UpdateInfoRequest) (*UpdateInfoRequest, error)
+	UpdateInfo(context.Context, *UpdateInfoRequest) (*UpdateInfoResponse, error)
 	SetState(context.Context, *SetStateRequest) (*SetStateResponse, error)
+	Erase(context.Context, *EraseRequest) (*EraseResponse, error)
+	SubjectAccessReport(context.Context, *SubjectAccessReportRequest) (*SubjectAccessReportResponse, error)
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	BatchGet(context.Context, *BatchGetRequest) (*BatchGetResponse, error)
+	BatchSetState(context.Context, *BatchSetStateRequest) (*BatchSetStateResponse, error)
+	GetAsOf(context.Context, *GetAsOfRequest) (*GetAsOfResponse, error)
+	CorrectInfo(context.Context, *CorrectInfoRequest) (*CorrectInfoResponse, error)
+	Merge(context.Context, *MergeRequest) (*MergeResponse, error)
+	Unmerge(context.Context, *UnmergeRequest) (*UnmergeResponse, error)
+	FindDuplicates(context.Context, *FindDuplicatesRequest) (*FindDuplicatesResponse, error)
+	ConvertType(context.Context, *ConvertTypeRequest) (*ConvertTypeResponse, error)
 	mustEmbedUnimplementedCustomerRegistryServer()
 }
 
@@ -89,12 +221,48 @@ func (UnimplementedCustomerRegistryServer) New(context.Context, *NewRequest) (*N
 func (UnimplementedCustomerRegistryServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
-func (UnimplementedCustomerRegistryServer) UpdateInfo(context.Context, *UpdateInfoRequest) (*UpdateInfoRequest, error) {
+func (UnimplementedCustomerRegistryServer) UpdateInfo(context.Context, *UpdateInfoRequest) (*UpdateInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateInfo not implemented")
 }
 func (UnimplementedCustomerRegistryServer) SetState(context.Context, *SetStateRequest) (*SetStateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetState not implemented")
 }
+func (UnimplementedCustomerRegistryServer) Erase(context.Context, *EraseRequest) (*EraseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Erase not implemented")
+}
+func (UnimplementedCustomerRegistryServer) SubjectAccessReport(context.Context, *SubjectAccessReportRequest) (*SubjectAccessReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubjectAccessReport not implemented")
+}
+func (UnimplementedCustomerRegistryServer) List(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedCustomerRegistryServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedCustomerRegistryServer) BatchGet(context.Context, *BatchGetRequest) (*BatchGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGet not implemented")
+}
+func (UnimplementedCustomerRegistryServer) BatchSetState(context.Context, *BatchSetStateRequest) (*BatchSetStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchSetState not implemented")
+}
+func (UnimplementedCustomerRegistryServer) GetAsOf(context.Context, *GetAsOfRequest) (*GetAsOfResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAsOf not implemented")
+}
+func (UnimplementedCustomerRegistryServer) CorrectInfo(context.Context, *CorrectInfoRequest) (*CorrectInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CorrectInfo not implemented")
+}
+func (UnimplementedCustomerRegistryServer) Merge(context.Context, *MergeRequest) (*MergeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Merge not implemented")
+}
+func (UnimplementedCustomerRegistryServer) Unmerge(context.Context, *UnmergeRequest) (*UnmergeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unmerge not implemented")
+}
+func (UnimplementedCustomerRegistryServer) FindDuplicates(context.Context, *FindDuplicatesRequest) (*FindDuplicatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindDuplicates not implemented")
+}
+func (UnimplementedCustomerRegistryServer) ConvertType(context.Context, *ConvertTypeRequest) (*ConvertTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertType not implemented")
+}
 func (UnimplementedCustomerRegistryServer) mustEmbedUnimplementedCustomerRegistryServer() {}
 
 // UnsafeCustomerRegistryServer may be embedded to opt out of forward compatibility for this service.
@@ -180,6 +348,222 @@ func _CustomerRegistry_SetState_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CustomerRegistry_Erase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerRegistryServer).Erase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CustomerRegistry/Erase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerRegistryServer).Erase(ctx, req.(*EraseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerRegistry_SubjectAccessReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubjectAccessReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerRegistryServer).SubjectAccessReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CustomerRegistry/SubjectAccessReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerRegistryServer).SubjectAccessReport(ctx, req.(*SubjectAccessReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerRegistry_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerRegistryServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CustomerRegistry/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerRegistryServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerRegistry_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerRegistryServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CustomerRegistry/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerRegistryServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerRegistry_BatchGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerRegistryServer).BatchGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CustomerRegistry/BatchGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerRegistryServer).BatchGet(ctx, req.(*BatchGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerRegistry_BatchSetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchSetStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerRegistryServer).BatchSetState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CustomerRegistry/BatchSetState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerRegistryServer).BatchSetState(ctx, req.(*BatchSetStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerRegistry_GetAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAsOfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerRegistryServer).GetAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CustomerRegistry/GetAsOf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerRegistryServer).GetAsOf(ctx, req.(*GetAsOfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerRegistry_CorrectInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CorrectInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerRegistryServer).CorrectInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CustomerRegistry/CorrectInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerRegistryServer).CorrectInfo(ctx, req.(*CorrectInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerRegistry_Merge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerRegistryServer).Merge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CustomerRegistry/Merge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerRegistryServer).Merge(ctx, req.(*MergeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerRegistry_Unmerge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnmergeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerRegistryServer).Unmerge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CustomerRegistry/Unmerge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerRegistryServer).Unmerge(ctx, req.(*UnmergeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerRegistry_FindDuplicates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindDuplicatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerRegistryServer).FindDuplicates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CustomerRegistry/FindDuplicates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerRegistryServer).FindDuplicates(ctx, req.(*FindDuplicatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerRegistry_ConvertType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerRegistryServer).ConvertType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CustomerRegistry/ConvertType",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerRegistryServer).ConvertType(ctx, req.(*ConvertTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CustomerRegistry_ServiceDesc is the grpc.ServiceDesc for CustomerRegistry service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -203,6 +587,54 @@ var CustomerRegistry_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetState",
 			Handler:    _CustomerRegistry_SetState_Handler,
 		},
+		{
+			MethodName: "Erase",
+			Handler:    _CustomerRegistry_Erase_Handler,
+		},
+		{
+			MethodName: "SubjectAccessReport",
+			Handler:    _CustomerRegistry_SubjectAccessReport_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _CustomerRegistry_List_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _CustomerRegistry_Search_Handler,
+		},
+		{
+			MethodName: "BatchGet",
+			Handler:    _CustomerRegistry_BatchGet_Handler,
+		},
+		{
+			MethodName: "BatchSetState",
+			Handler:    _CustomerRegistry_BatchSetState_Handler,
+		},
+		{
+			MethodName: "GetAsOf",
+			Handler:    _CustomerRegistry_GetAsOf_Handler,
+		},
+		{
+			MethodName: "CorrectInfo",
+			Handler:    _CustomerRegistry_CorrectInfo_Handler,
+		},
+		{
+			MethodName: "Merge",
+			Handler:    _CustomerRegistry_Merge_Handler,
+		},
+		{
+			MethodName: "Unmerge",
+			Handler:    _CustomerRegistry_Unmerge_Handler,
+		},
+		{
+			MethodName: "FindDuplicates",
+			Handler:    _CustomerRegistry_FindDuplicates_Handler,
+		},
+		{
+			MethodName: "ConvertType",
+			Handler:    _CustomerRegistry_ConvertType_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pb/customer.proto",
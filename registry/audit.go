@@ -0,0 +1,59 @@
+package registry
+
+import (
+	"context"
+	"time"
+
+	"github.com/nacobas/customer/auth"
+	"github.com/nacobas/customer/customer"
+)
+
+const (
+	OpNew        = "New"
+	OpUpdateInfo = "UpdateInfo"
+	OpSetState   = "SetState"
+	OpErase      = "Erase"
+)
+
+// AuditEntry records a change to a customer. Entries never hold customer info values so
+// they can be kept after the customer has been erased.
+type AuditEntry struct {
+	CustomerID uint32         `json:"customer_id"`
+	Time       time.Time      `json:"time"`
+	Op         string         `json:"op"`
+	Actor      string         `json:"actor,omitempty"`
+	From       customer.State `json:"from,omitempty"`
+	To         customer.State `json:"to,omitempty"`
+	// Related is the other customer of a merge
+	Related uint32 `json:"related,omitempty"`
+	// FromType and ToType are the customer types of a type conversion
+	FromType customer.CustomerType `json:"from_type,omitempty"`
+	ToType   customer.CustomerType `json:"to_type,omitempty"`
+}
+
+type AuditLog interface {
+	Record(ctx context.Context, e AuditEntry) error
+	Entries(ctx context.Context, id uint32) ([]AuditEntry, error)
+}
+
+func (svc *service) record(ctx context.Context, e AuditEntry) error {
+	if svc.audit == nil {
+		return nil
+	}
+
+	e.Time = time.Now()
+
+	if p, ok := auth.FromContext(ctx); ok {
+		e.Actor = p.Subject
+	}
+
+	return svc.audit.Record(ctx, e)
+}
+
+func (svc *service) entries(ctx context.Context, id uint32) ([]AuditEntry, error) {
+	if svc.audit == nil {
+		return nil, nil
+	}
+
+	return svc.audit.Entries(ctx, id)
+}
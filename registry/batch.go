@@ -0,0 +1,139 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+)
+
+// MaxBatch is the largest number of items accepted by a batch operation
+const MaxBatch = MaxLimit
+
+// BatchResult is the outcome of one item of a batch, Err carries the same marks as the error
+// of the single item operation
+type BatchResult struct {
+	ID       uint32
+	Customer *customer.Customer
+	Err      error
+}
+
+// StateUpdate is one item of BatchSetState
+type StateUpdate struct {
+	ID    uint32
+	State customer.State
+}
+
+// BatchGet returns a result per ID in the order of ids. The error is only set when the
+// batch as a whole is rejected.
+func (svc *service) BatchGet(ctx context.Context, ids []uint32) ([]BatchResult, error) {
+	const op string = "registry.Service.BatchGet"
+
+	if err := svc.authorize(ctx, PermRead); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrPermission)
+	}
+
+	if err := svc.validate.Var(ids, "min=1,max=1000"); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrValidation)
+	}
+
+	cs, err := svc.repo.BatchGet(ctx, ids)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+	}
+
+	results := make([]BatchResult, len(ids))
+	for i, id := range ids {
+		results[i].ID = id
+
+		if cs[i] == nil {
+			results[i].Err = errors.Mark(errors.Wrapf(ErrNotFound, "%s: %d", op, id), ErrNotFound)
+			continue
+		}
+
+		if results[i].Customer, err = svc.follow(ctx, cs[i]); err != nil {
+			results[i].Err = errors.Mark(errors.Wrapf(err, "%s: %d", op, id), ErrUnexpected)
+		}
+	}
+
+	return results, nil
+}
+
+// BatchSetState sets the state of every customer of the batch, items that fail do not stop
+// the others
+func (svc *service) BatchSetState(ctx context.Context, updates []StateUpdate) ([]BatchResult, error) {
+	const op string = "registry.Service.BatchSetState"
+
+	if err := svc.authorize(ctx, PermAdmin); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrPermission)
+	}
+
+	if err := svc.validate.Var(updates, "min=1,max=1000"); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrValidation)
+	}
+
+	ids := make([]uint32, len(updates))
+	for i, u := range updates {
+		ids[i] = u.ID
+	}
+
+	defer svc.locks.lock(ctx, ids...)()
+
+	cs, err := svc.repo.BatchGet(ctx, ids)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+	}
+
+	var (
+		results = make([]BatchResult, len(updates))
+		from    = make([]customer.State, len(updates))
+		pending []int
+		changed []*customer.Customer
+	)
+
+	for i, u := range updates {
+		results[i].ID = u.ID
+
+		if err := svc.validate.Var(u.State, "min=1,max=3"); err != nil {
+			results[i].Err = errors.Mark(errors.Wrapf(err, "%s: %d", op, u.ID), ErrValidation)
+			continue
+		}
+
+		c := cs[i]
+		if c == nil {
+			results[i].Err = errors.Mark(errors.Wrapf(ErrNotFound, "%s: %d", op, u.ID), ErrNotFound)
+			continue
+		}
+
+		from[i] = c.State
+
+		if err := c.SetState(u.State, svc.ages); err != nil {
+			results[i].Err = errors.Mark(errors.Wrapf(err, "%s: %d", op, u.ID), ErrExpected)
+			continue
+		}
+
+		pending = append(pending, i)
+		changed = append(changed, c)
+	}
+
+	errs, err := svc.repo.BatchUpdate(ctx, changed)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+	}
+
+	for j, i := range pending {
+		if errs[j] != nil {
+			results[i].Err = errors.Mark(errors.Wrapf(errs[j], "%s: %d", op, results[i].ID), ErrUnexpected)
+			continue
+		}
+
+		results[i].Customer = changed[j]
+
+		e := AuditEntry{CustomerID: results[i].ID, Op: OpSetState, From: from[i], To: updates[i].State}
+		if err := svc.record(ctx, e); err != nil {
+			return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,36 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+)
+
+const OpConvertType = "ConvertType"
+
+// ConvertType replaces the info of a prospect entered as the wrong type, for example a
+// person that should have been an organization. The customer keeps its ID. UpdateInfo only
+// allows the conversions between private customers and sole traders of the same person.
+func (svc *service) ConvertType(ctx context.Context, id uint32, i customer.Info) (*customer.Customer, error) {
+	const op string = "registry.Service.ConvertType"
+
+	if err := svc.authorize(ctx, PermConvert); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrPermission)
+	}
+
+	ref, err := svc.check(ctx, i)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrValidation)
+	}
+
+	c, err := svc.changeInfo(ctx, id, OpConvertType, func(c *customer.Customer) (InfoVersion, error) {
+		if err := c.ConvertType(i); err != nil {
+			return InfoVersion{}, errors.Mark(err, ErrExpected)
+		}
+		c.Profile = ref
+		return newVersion(i), nil
+	})
+
+	return c, errors.Wrap(err, op)
+}
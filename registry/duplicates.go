@@ -0,0 +1,93 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+)
+
+// DefaultDuplicateScore is the minimum score of reported duplicates when none is given
+const DefaultDuplicateScore = 0.8
+
+var (
+	ErrNoDuplicateFinder = errors.New("Duplicate detection not enabled")
+)
+
+// Duplicate is a pair of customers that are probably the same, ID is the lower of the two.
+// Score is between 0 and 1, Reasons explain it without customer data.
+type Duplicate struct {
+	ID      uint32   `json:"id"`
+	OtherID uint32   `json:"other_id"`
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
+// DuplicateFinder returns the pairs of customers scoring at least minScore, highest first
+type DuplicateFinder interface {
+	Duplicates(ctx context.Context, cs []*customer.Customer, minScore float64) ([]Duplicate, error)
+}
+
+// WithDuplicateFinder enables FindDuplicates
+func WithDuplicateFinder(df DuplicateFinder) Option {
+	return func(svc *service) {
+		svc.duplicates = df
+	}
+}
+
+// FindDuplicates ranks probable duplicates among all customers of the tenant, erased and
+// merged customers excluded. Zero minScore selects DefaultDuplicateScore.
+func (svc *service) FindDuplicates(ctx context.Context, minScore float64, limit int) ([]Duplicate, error) {
+	const op string = "registry.Service.FindDuplicates"
+
+	if err := svc.authorize(ctx, PermAdmin); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrPermission)
+	}
+
+	if svc.duplicates == nil {
+		return nil, errors.Mark(errors.Wrap(ErrNoDuplicateFinder, op), ErrExpected)
+	}
+
+	if minScore == 0 {
+		minScore = DefaultDuplicateScore
+	}
+
+	if err := svc.validate.Var(minScore, "gt=0,lte=1"); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrValidation)
+	}
+
+	limit, err := svc.limit(limit)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrValidation)
+	}
+
+	var (
+		cs    []*customer.Customer
+		after uint32
+	)
+
+	for {
+		page, err := svc.repo.List(ctx, after, MaxLimit)
+		if err != nil {
+			return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+		}
+
+		cs = append(cs, page...)
+
+		if len(page) < MaxLimit {
+			break
+		}
+		after = page[len(page)-1].ID
+	}
+
+	ds, err := svc.duplicates.Duplicates(ctx, cs, minScore)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+	}
+
+	if len(ds) > limit {
+		ds = ds[:limit]
+	}
+
+	return ds, nil
+}
@@ -0,0 +1,205 @@
+package registry
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+)
+
+const OpCorrectInfo = "CorrectInfo"
+
+var (
+	ErrNoHistory     = errors.New("Info history not enabled")
+	ErrInvalidPeriod = errors.New("Invalid validity period")
+)
+
+// InfoVersion is customer info as it was valid from ValidFrom until ValidTo and as it was
+// known from RecordedAt until SupersededAt. Zero ValidTo and SupersededAt are open ended,
+// zero ValidFrom and RecordedAt mean the version predates the history.
+type InfoVersion struct {
+	Info         customer.Info `json:"info"`
+	ValidFrom    time.Time     `json:"valid_from"`
+	ValidTo      time.Time     `json:"valid_to"`
+	RecordedAt   time.Time     `json:"recorded_at"`
+	SupersededAt time.Time     `json:"superseded_at"`
+}
+
+// ValidAt reports whether the version was valid at t
+func (v *InfoVersion) ValidAt(t time.Time) bool {
+	return !v.ValidFrom.After(t) && (v.ValidTo.IsZero() || v.ValidTo.After(t))
+}
+
+// KnownAt reports whether the version was recorded and not yet superseded at t
+func (v *InfoVersion) KnownAt(t time.Time) bool {
+	return !v.RecordedAt.After(t) && (v.SupersededAt.IsZero() || v.SupersededAt.After(t))
+}
+
+// InfoHistory stores the versions of customer info. Versions are never changed except for
+// SupersededAt, so earlier knowledge stays queryable after a correction. The history holds
+// info values, stores seal identifiers at rest as the Repo does, and is purged on Erase.
+type InfoHistory interface {
+	Purger
+	// Versions returns the versions of a customer in the order they were recorded
+	Versions(ctx context.Context, id uint32) ([]InfoVersion, error)
+	// Record records v as Correct does, atomically with respect to other calls for the same
+	// customer. prev is recorded first as valid since ever when the customer has no versions,
+	// nil for none.
+	Record(ctx context.Context, id uint32, prev customer.Info, v InfoVersion) error
+}
+
+// WithInfoHistory records every version of customer info and enables GetAsOf and CorrectInfo
+func WithInfoHistory(h InfoHistory) Option {
+	return func(svc *service) {
+		svc.history = h
+		svc.purgers = append(svc.purgers, h)
+	}
+}
+
+// GetAsOf returns the info of a customer valid at validTime as it was known at recordedTime,
+// zero times select now
+func (svc *service) GetAsOf(ctx context.Context, id uint32, validTime, recordedTime time.Time) (*InfoVersion, error) {
+	const op string = "registry.Service.GetAsOf"
+
+	if err := svc.authorize(ctx, PermRead); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrPermission)
+	}
+
+	if svc.history == nil {
+		return nil, errors.Mark(errors.Wrap(ErrNoHistory, op), ErrExpected)
+	}
+
+	c, err := svc.repo.Get(ctx, id)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrNotFound)
+	}
+
+	if c.Erased {
+		return nil, errors.Mark(errors.Wrap(customer.ErrErased, op), ErrExpected)
+	}
+
+	vs, err := svc.versions(ctx, c)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+	}
+
+	now := time.Now()
+	if validTime.IsZero() {
+		validTime = now
+	}
+	if recordedTime.IsZero() {
+		recordedTime = now
+	}
+
+	for i := len(vs) - 1; i >= 0; i-- {
+		if vs[i].ValidAt(validTime) && vs[i].KnownAt(recordedTime) {
+			return &vs[i], nil
+		}
+	}
+
+	return nil, errors.Mark(errors.Wrapf(ErrNotFound, "%s: %d valid at %s recorded at %s", op, id,
+		validTime.Format(time.RFC3339), recordedTime.Format(time.RFC3339)), ErrNotFound)
+}
+
+// CorrectInfo records info valid from validFrom until validTo, zero validTo for still valid,
+// superseding what was known for that period without forgetting it. The customer info is
+// updated when the correction is still valid. A correction ending in the future is rejected,
+// nothing would restore the current info once it ends.
+func (svc *service) CorrectInfo(ctx context.Context, id uint32, i customer.Info, validFrom, validTo time.Time) (*customer.Customer, error) {
+	const op string = "registry.Service.CorrectInfo"
+
+	if err := svc.authorize(ctx, PermWrite); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrPermission)
+	}
+
+	if svc.history == nil {
+		return nil, errors.Mark(errors.Wrap(ErrNoHistory, op), ErrExpected)
+	}
+
+	ref, err := svc.check(ctx, i)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrValidation)
+	}
+
+	now := time.Now()
+
+	if validFrom.IsZero() || validFrom.After(now) || (!validTo.IsZero() && (!validTo.After(validFrom) || validTo.After(now))) {
+		return nil, errors.Mark(errors.Wrap(ErrInvalidPeriod, op), ErrValidation)
+	}
+
+	c, err := svc.changeInfo(ctx, id, OpCorrectInfo, func(c *customer.Customer) (InfoVersion, error) {
+		if err := c.UpdateInfo(i); err != nil {
+			return InfoVersion{}, errors.Mark(err, ErrExpected)
+		}
+		c.Profile = ref
+		return InfoVersion{Info: i, ValidFrom: validFrom, ValidTo: validTo, RecordedAt: now}, nil
+	})
+
+	return c, errors.Wrap(err, op)
+}
+
+// versions returns the history of c, customers without history have their current info as
+// the only version
+func (svc *service) versions(ctx context.Context, c *customer.Customer) ([]InfoVersion, error) {
+	vs, err := svc.history.Versions(ctx, c.ID)
+	if err != nil || len(vs) > 0 {
+		return vs, err
+	}
+
+	return []InfoVersion{{Info: c.Info}}, nil
+}
+
+// newVersion returns i as valid and recorded from now on
+func newVersion(i customer.Info) InfoVersion {
+	now := time.Now()
+	return InfoVersion{Info: i, ValidFrom: now, RecordedAt: now}
+}
+
+// recordVersion records v in the history, prev is the info of customers created before the
+// history was enabled, nil for new customers
+func (svc *service) recordVersion(ctx context.Context, id uint32, prev customer.Info, v InfoVersion) error {
+	if svc.history == nil {
+		return nil
+	}
+
+	return svc.history.Record(ctx, id, prev, v)
+}
+
+// Correct returns the indices of the current versions of vs overlapping the valid time of v,
+// to be superseded at v.RecordedAt, and the versions to record in their place: the parts of
+// them outside the valid time of v and v. InfoHistory implementations call it under the
+// same lock as the write.
+func Correct(vs []InfoVersion, v InfoVersion) ([]int, []InfoVersion) {
+	var (
+		superseded []int
+		recorded   []InfoVersion
+	)
+
+	for i, o := range vs {
+		if !o.SupersededAt.IsZero() || !overlaps(&o, &v) {
+			continue
+		}
+
+		superseded = append(superseded, i)
+
+		if o.ValidFrom.Before(v.ValidFrom) {
+			head := o
+			head.ValidTo, head.RecordedAt = v.ValidFrom, v.RecordedAt
+			recorded = append(recorded, head)
+		}
+
+		if !v.ValidTo.IsZero() && (o.ValidTo.IsZero() || o.ValidTo.After(v.ValidTo)) {
+			tail := o
+			tail.ValidFrom, tail.RecordedAt = v.ValidTo, v.RecordedAt
+			recorded = append(recorded, tail)
+		}
+	}
+
+	return superseded, append(recorded, v)
+}
+
+func overlaps(a, b *InfoVersion) bool {
+	return (a.ValidTo.IsZero() || a.ValidTo.After(b.ValidFrom)) &&
+		(b.ValidTo.IsZero() || b.ValidTo.After(a.ValidFrom))
+}
@@ -0,0 +1,46 @@
+package registry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/tenant"
+)
+
+// NewLegalHolds returns a LegalHold where holds are placed and released per customer
+func NewLegalHolds() *LegalHolds {
+	return &LegalHolds{holds: map[holdKey]time.Time{}}
+}
+
+type LegalHolds struct {
+	mtx   sync.RWMutex
+	holds map[holdKey]time.Time
+}
+
+type holdKey struct {
+	tenant string
+	id     uint32
+}
+
+func (lh *LegalHolds) Place(ctx context.Context, id uint32, until time.Time) {
+	lh.mtx.Lock()
+	defer lh.mtx.Unlock()
+
+	lh.holds[holdKey{tenant.FromContext(ctx), id}] = until
+}
+
+func (lh *LegalHolds) Release(ctx context.Context, id uint32) {
+	lh.mtx.Lock()
+	defer lh.mtx.Unlock()
+
+	delete(lh.holds, holdKey{tenant.FromContext(ctx), id})
+}
+
+func (lh *LegalHolds) Until(ctx context.Context, c *customer.Customer) (time.Time, error) {
+	lh.mtx.RLock()
+	defer lh.mtx.RUnlock()
+
+	return lh.holds[holdKey{tenant.FromContext(ctx), c.ID}], nil
+}
@@ -0,0 +1,110 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+)
+
+var (
+	ErrIdempotencyMismatch   = errors.New("Idempotency key reused with a different request")
+	ErrIdempotencyInProgress = errors.New("Request with the same idempotency key in progress")
+)
+
+// IdempotencyStore remembers the customer created for an idempotency key. Keys are scoped
+// to the tenant of the context and forgotten after a store specific TTL.
+type IdempotencyStore interface {
+	// Claim reserves key for a request with fingerprint. When the key is already known the
+	// existing record is returned and claimed is false.
+	Claim(ctx context.Context, key, fingerprint string) (rec IdempotencyRecord, claimed bool, err error)
+	// Complete records the customer created under a claimed key
+	Complete(ctx context.Context, key string, id uint32) error
+	// Release forgets a claimed key after a failed request so it can be retried
+	Release(ctx context.Context, key string) error
+}
+
+// IdempotencyRecord is the result of a request, CustomerID is zero while it is in progress
+type IdempotencyRecord struct {
+	Fingerprint string
+	CustomerID  uint32
+}
+
+// WithIdempotency makes New return the original customer when a request is retried with
+// the same idempotency key
+func WithIdempotency(is IdempotencyStore) Option {
+	return func(svc *service) {
+		svc.idempotency = is
+	}
+}
+
+type idempotencyKey struct{}
+
+// NewIdempotencyContext attaches the idempotency key of a New request to ctx
+func NewIdempotencyContext(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the idempotency key of the request or an empty string
+func IdempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKey{}).(string)
+	return key
+}
+
+// fingerprint identifies the payload of a New request
+func fingerprint(i customer.Info) (string, error) {
+	b, err := json.Marshal(i)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(append([]byte(i.Type().String()+":"), b...))
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// replay returns the customer of an earlier request with the same key, or the claimed key
+// which the caller must complete or release
+func (svc *service) replay(ctx context.Context, i customer.Info) (c *customer.Customer, key string, err error) {
+	key = IdempotencyKeyFromContext(ctx)
+	if key == "" || svc.idempotency == nil {
+		return nil, "", nil
+	}
+
+	fp, err := fingerprint(i)
+	if err != nil {
+		return nil, "", errors.Mark(err, ErrUnexpected)
+	}
+
+	rec, claimed, err := svc.idempotency.Claim(ctx, key, fp)
+	if err != nil {
+		return nil, "", errors.Mark(err, ErrUnexpected)
+	}
+
+	if claimed {
+		return nil, key, nil
+	}
+
+	if rec.Fingerprint != fp {
+		return nil, "", errors.Mark(ErrIdempotencyMismatch, ErrValidation)
+	}
+
+	if rec.CustomerID == 0 {
+		return nil, "", errors.Mark(ErrIdempotencyInProgress, ErrExpected)
+	}
+
+	c, err = svc.repo.Get(ctx, rec.CustomerID)
+	if err != nil {
+		return nil, "", errors.Mark(err, ErrNotFound)
+	}
+
+	// the customer created may have been merged since
+	if c, err = svc.follow(ctx, c); err != nil {
+		return nil, "", errors.Mark(err, ErrUnexpected)
+	}
+
+	return c, "", nil
+}
@@ -0,0 +1,82 @@
+package registry
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/nacobas/customer/tenant"
+)
+
+// customerLocks serialise reading, changing and writing back customers, the Repo has no
+// compare-and-swap. A lock is kept only while held or waited for.
+type customerLocks struct {
+	mtx   sync.Mutex
+	locks map[lockKey]*customerLock
+}
+
+type lockKey struct {
+	tenant string
+	id     uint32
+}
+
+type customerLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lock locks the customers ids of the request tenant in ID order, so that callers locking
+// several customers do not deadlock, and returns the function unlocking them
+func (cl *customerLocks) lock(ctx context.Context, ids ...uint32) (unlock func()) {
+	t := tenant.FromContext(ctx)
+
+	sorted := append([]uint32(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var held []lockKey
+	for i, id := range sorted {
+		if i > 0 && id == sorted[i-1] {
+			continue
+		}
+
+		k := lockKey{t, id}
+		cl.acquire(k).Lock()
+		held = append(held, k)
+	}
+
+	return func() {
+		for i := len(held) - 1; i >= 0; i-- {
+			cl.release(held[i])
+		}
+	}
+}
+
+func (cl *customerLocks) acquire(k lockKey) *customerLock {
+	cl.mtx.Lock()
+	defer cl.mtx.Unlock()
+
+	if cl.locks == nil {
+		cl.locks = map[lockKey]*customerLock{}
+	}
+
+	l, ok := cl.locks[k]
+	if !ok {
+		l = &customerLock{}
+		cl.locks[k] = l
+	}
+	l.refs++
+
+	return l
+}
+
+func (cl *customerLocks) release(k lockKey) {
+	cl.mtx.Lock()
+	defer cl.mtx.Unlock()
+
+	l := cl.locks[k]
+	l.Unlock()
+
+	if l.refs--; l.refs == 0 {
+		delete(cl.locks, k)
+	}
+}
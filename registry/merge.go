@@ -0,0 +1,145 @@
+package registry
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+)
+
+const (
+	OpMerge   = "Merge"
+	OpUnmerge = "Unmerge"
+)
+
+// DefaultMergeGracePeriod is how long a merge can be undone
+const DefaultMergeGracePeriod = 30 * 24 * time.Hour
+
+// maxRedirects bounds the chain of merges followed to the survivor
+const maxRedirects = 8
+
+var (
+	ErrSelfMerge    = errors.New("Customer merged into itself")
+	ErrGraceExpired = errors.New("Merge grace period expired")
+)
+
+// WithMergeGracePeriod sets how long a merge can be undone with Unmerge
+func WithMergeGracePeriod(d time.Duration) Option {
+	return func(svc *service) {
+		svc.mergeGrace = d
+	}
+}
+
+// Merge folds the source customer into the target. The source is kept as a redirect, so Get
+// of its ID returns the target, and keeps its info for Unmerge. Relationships and contacts
+// are not modelled by the registry, so there is nothing else to move.
+func (svc *service) Merge(ctx context.Context, sourceID, targetID uint32) (*customer.Customer, error) {
+	const op string = "registry.Service.Merge"
+
+	if err := svc.authorize(ctx, PermAdmin); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrPermission)
+	}
+
+	if sourceID == targetID {
+		return nil, errors.Mark(errors.Wrap(ErrSelfMerge, op), ErrValidation)
+	}
+
+	defer svc.locks.lock(ctx, sourceID, targetID)()
+
+	source, err := svc.repo.Get(ctx, sourceID)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrNotFound)
+	}
+
+	target, err := svc.repo.Get(ctx, targetID)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrNotFound)
+	}
+
+	// merging again into the same target is a no-op, so retries are safe. The target may have
+	// been merged since.
+	if source.MergedInto == targetID {
+		if target, err = svc.follow(ctx, target); err != nil {
+			return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+		}
+		return target, nil
+	}
+
+	if err := source.MergeInto(target, time.Now()); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrExpected)
+	}
+
+	if err := svc.repo.Update(ctx, source); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+	}
+
+	for _, e := range []AuditEntry{
+		{CustomerID: sourceID, Op: OpMerge, Related: targetID},
+		{CustomerID: targetID, Op: OpMerge, Related: sourceID},
+	} {
+		if err := svc.record(ctx, e); err != nil {
+			return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+		}
+	}
+
+	return target, nil
+}
+
+// Unmerge restores a merged customer within the merge grace period. Customers sharing an
+// identifier with the survivor can not be restored, the repo rejects them with ErrConflict.
+func (svc *service) Unmerge(ctx context.Context, id uint32) (*customer.Customer, error) {
+	const op string = "registry.Service.Unmerge"
+
+	if err := svc.authorize(ctx, PermAdmin); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrPermission)
+	}
+
+	defer svc.locks.lock(ctx, id)()
+
+	c, err := svc.repo.Get(ctx, id)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrNotFound)
+	}
+
+	target := c.MergedInto
+
+	if target != 0 && time.Since(c.MergedAt) > svc.mergeGrace {
+		return nil, errors.Mark(errors.Wrapf(ErrGraceExpired, "%s: merged at %s", op, c.MergedAt.Format(time.RFC3339)), ErrExpected)
+	}
+
+	if err := c.Unmerge(); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrExpected)
+	}
+
+	if err := svc.repo.Update(ctx, c); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+	}
+
+	for _, e := range []AuditEntry{
+		{CustomerID: id, Op: OpUnmerge, Related: target},
+		{CustomerID: target, Op: OpUnmerge, Related: id},
+	} {
+		if err := svc.record(ctx, e); err != nil {
+			return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+		}
+	}
+
+	return c, nil
+}
+
+// follow returns the survivor of the merges of c, c itself when it has not been merged
+func (svc *service) follow(ctx context.Context, c *customer.Customer) (*customer.Customer, error) {
+	for n := 0; c.MergedInto != 0; n++ {
+		if n == maxRedirects {
+			return nil, errors.Newf("more than %d redirects from %d", maxRedirects, c.ID)
+		}
+
+		var err error
+		if c, err = svc.repo.Get(ctx, c.MergedInto); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,106 @@
+package registry
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/tenant"
+)
+
+var ErrDuplicateProfile = errors.New("Duplicate validation profile")
+
+// ProfileBinding selects a validation profile for requests of the listed tenants and for info
+// of the listed countries, the citizenship of persons and sole traders and the registration
+// country of organizations
+type ProfileBinding struct {
+	Profile   customer.Profile
+	Tenants   []string
+	Countries []string
+}
+
+// Profiles are compiled validation profiles, see CompileProfiles
+type Profiles []compiledProfile
+
+type compiledProfile struct {
+	ProfileBinding
+	validate *validator.Validate
+}
+
+// CompileProfiles compiles the rules of each profile into a validator with age rules r,
+// profile names must be unique
+func CompileProfiles(bs []ProfileBinding, r customer.AgeRules) (Profiles, error) {
+	const op string = "registry.CompileProfiles"
+
+	ps := make(Profiles, 0, len(bs))
+	names := map[string]bool{}
+
+	for _, b := range bs {
+		if names[b.Profile.Name] {
+			return nil, errors.Wrapf(ErrDuplicateProfile, "%s: %q", op, b.Profile.Name)
+		}
+		names[b.Profile.Name] = true
+
+		v, err := customer.NewProfileValidator(b.Profile, r)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		ps = append(ps, compiledProfile{ProfileBinding: b, validate: v})
+	}
+
+	return ps, nil
+}
+
+// WithValidationProfiles validates info against the first profile bound to the tenant of the
+// request or, when no profile is bound to the tenant, the first bound to the country of the
+// info. Info no profile applies to is validated by the built-in rules only.
+func WithValidationProfiles(ps Profiles) Option {
+	return func(svc *service) {
+		svc.profiles = ps
+	}
+}
+
+// selectFor returns the profile applying to info i in the request, nil for none
+func (ps Profiles) selectFor(ctx context.Context, i customer.Info) *compiledProfile {
+	t := tenant.FromContext(ctx)
+	for idx := range ps {
+		if contains(ps[idx].Tenants, t) {
+			return &ps[idx]
+		}
+	}
+
+	c := country(i)
+	if c == "" {
+		return nil
+	}
+	for idx := range ps {
+		for _, bc := range ps[idx].Countries {
+			if strings.EqualFold(bc, c) {
+				return &ps[idx]
+			}
+		}
+	}
+
+	return nil
+}
+
+func country(i customer.Info) string {
+	if pi, ok := customer.PersonOf(i); ok {
+		return pi.Citizenship
+	}
+	if oi, ok := i.(*customer.OrganizationInfo); ok {
+		return oi.RegistrationCountry
+	}
+	return ""
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
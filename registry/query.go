@@ -0,0 +1,67 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+)
+
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// List pages through customers ordered by ID, pass the ID of the last customer of a page as
+// after to get the next page
+func (svc *service) List(ctx context.Context, after uint32, limit int) ([]*customer.Customer, error) {
+	const op string = "registry.Service.List"
+
+	if err := svc.authorize(ctx, PermRead); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrPermission)
+	}
+
+	limit, err := svc.limit(limit)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrValidation)
+	}
+
+	cs, err := svc.repo.List(ctx, after, limit)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+	}
+
+	return cs, nil
+}
+
+func (svc *service) Search(ctx context.Context, query string, limit int) ([]*customer.Customer, error) {
+	const op string = "registry.Service.Search"
+
+	if err := svc.authorize(ctx, PermRead); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrPermission)
+	}
+
+	if err := svc.validate.Var(query, "min=2"); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrValidation)
+	}
+
+	limit, err := svc.limit(limit)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrValidation)
+	}
+
+	cs, err := svc.repo.Search(ctx, query, limit)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+	}
+
+	return cs, nil
+}
+
+func (svc *service) limit(limit int) (int, error) {
+	if limit == 0 {
+		return DefaultLimit, nil
+	}
+
+	return limit, svc.validate.Var(limit, "min=1,max=1000")
+}
@@ -0,0 +1,211 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+)
+
+// SubjectAccessReport holds all data kept on a data subject (GDPR Art. 15). Contact points
+// and relationships are not modelled by the registry and therefore not part of the report.
+type SubjectAccessReport struct {
+	SSN         string          `json:"ssn"`
+	GeneratedAt time.Time       `json:"generated_at"`
+	Customers   []SubjectRecord `json:"customers"`
+}
+
+type SubjectRecord struct {
+	ID           uint32        `json:"id"`
+	Type         string        `json:"type"`
+	State        string        `json:"state"`
+	Info         customer.Info `json:"info"`
+	Risk         RiskRecord    `json:"risk"`
+	StateHistory []StateChange `json:"state_history"`
+	Audit        []AuditEntry  `json:"audit"`
+	// InfoHistory are the recorded versions of the info, when info history is enabled
+	InfoHistory []InfoVersion `json:"info_history,omitempty"`
+	// MergedInto is the survivor of a merged customer, whose record is kept for Unmerge
+	MergedInto uint32     `json:"merged_into,omitempty"`
+	MergedAt   *time.Time `json:"merged_at,omitempty"`
+}
+
+type RiskRecord struct {
+	Rating  string   `json:"rating"`
+	Reasons []string `json:"reasons"`
+}
+
+type StateChange struct {
+	Time time.Time `json:"time"`
+	From string    `json:"from,omitempty"`
+	To   string    `json:"to"`
+}
+
+func (svc *service) SubjectAccessReport(ctx context.Context, ssn string) (*SubjectAccessReport, error) {
+	const op string = "registry.Service.SubjectAccessReport"
+
+	if err := svc.authorize(ctx, PermAdmin); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrPermission)
+	}
+
+	if err := svc.validate.Var(ssn, "required"); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrValidation)
+	}
+
+	cs, err := svc.repo.FindBySSN(ctx, ssn)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+	}
+
+	merged, err := svc.repo.FindMergedBySSN(ctx, ssn)
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+	}
+
+	cs = append(cs, merged...)
+	sort.Slice(cs, func(i, j int) bool { return cs[i].ID < cs[j].ID })
+
+	if len(cs) == 0 {
+		return nil, errors.Mark(errors.Wrap(ErrNotFound, op), ErrNotFound)
+	}
+
+	r := &SubjectAccessReport{SSN: ssn, GeneratedAt: time.Now()}
+
+	for _, c := range cs {
+		entries, err := svc.entries(ctx, c.ID)
+		if err != nil {
+			return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+		}
+
+		var versions []InfoVersion
+		if svc.history != nil {
+			if versions, err = svc.history.Versions(ctx, c.ID); err != nil {
+				return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+			}
+		}
+
+		sr := SubjectRecord{
+			ID:           c.ID,
+			Type:         c.Type().String(),
+			State:        c.State.String(),
+			Info:         c.Info,
+			Risk:         RiskRecord{Rating: c.Risk.Rating.String(), Reasons: c.Risk.Reasons},
+			StateHistory: stateHistory(entries),
+			Audit:        entries,
+			InfoHistory:  versions,
+		}
+		if c.MergedInto != 0 {
+			mergedAt := c.MergedAt
+			sr.MergedInto, sr.MergedAt = c.MergedInto, &mergedAt
+		}
+
+		r.Customers = append(r.Customers, sr)
+	}
+
+	return r, nil
+}
+
+func stateHistory(entries []AuditEntry) []StateChange {
+	var h []StateChange
+
+	for _, e := range entries {
+		if e.Op != OpNew && e.Op != OpSetState {
+			continue
+		}
+
+		sc := StateChange{Time: e.Time, To: e.To.String()}
+		if e.Op == OpSetState {
+			sc.From = e.From.String()
+		}
+		h = append(h, sc)
+	}
+
+	return h
+}
+
+// period renders an end of a version period, zero ends are open
+func period(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
+
+// Text renders the report for humans
+func (r *SubjectAccessReport) Text() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Subject access report for %s\n", r.SSN)
+	fmt.Fprintf(&b, "Generated at %s\n", r.GeneratedAt.Format(time.RFC3339))
+
+	for _, c := range r.Customers {
+		fmt.Fprintf(&b, "\nCustomer %d (%s)\n", c.ID, c.Type)
+
+		w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(w, "  State:\t%s\n", c.State)
+		if c.MergedInto != 0 {
+			fmt.Fprintf(w, "  Merged into:\tcustomer %d at %s\n", c.MergedInto, c.MergedAt.Format(time.RFC3339))
+		}
+		if i, ok := customer.PersonOf(c.Info); ok {
+			fmt.Fprintf(w, "  Given name:\t%s\n", i.GivenName)
+			if len(i.MiddleNames) > 0 {
+				fmt.Fprintf(w, "  Middle names:\t%s\n", strings.Join(i.MiddleNames, ", "))
+			}
+			if i.FamilyNamePrefix != "" {
+				fmt.Fprintf(w, "  Family name prefix:\t%s\n", i.FamilyNamePrefix)
+			}
+			fmt.Fprintf(w, "  Family name:\t%s\n", i.FamilyName)
+			fmt.Fprintf(w, "  SSN:\t%s\n", i.SSN)
+			fmt.Fprintf(w, "  Date of birth:\t%s\n", i.DateOfBirth)
+			fmt.Fprintf(w, "  Citizenship:\t%s\n", i.Citizenship)
+		}
+		if i, ok := c.Info.(*customer.SoleTraderInfo); ok {
+			fmt.Fprintf(w, "  Trade name:\t%s\n", i.TradeName)
+			fmt.Fprintf(w, "  Business ID:\t%s\n", i.BusinessID)
+		}
+		fmt.Fprintf(w, "  Risk rating:\t%s\n", c.Risk.Rating)
+		for _, reason := range c.Risk.Reasons {
+			fmt.Fprintf(w, "\t- %s\n", reason)
+		}
+		w.Flush()
+
+		if len(c.StateHistory) > 0 {
+			fmt.Fprintf(&b, "  State history:\n")
+			for _, sc := range c.StateHistory {
+				if sc.From == "" {
+					fmt.Fprintf(&b, "    %s  %s\n", sc.Time.Format(time.RFC3339), sc.To)
+					continue
+				}
+				fmt.Fprintf(&b, "    %s  %s -> %s\n", sc.Time.Format(time.RFC3339), sc.From, sc.To)
+			}
+		}
+
+		if len(c.InfoHistory) > 0 {
+			fmt.Fprintf(&b, "  Info history:\n")
+			for _, v := range c.InfoHistory {
+				fmt.Fprintf(&b, "    valid %s to %s, recorded %s to %s\n", period(v.ValidFrom), period(v.ValidTo),
+					period(v.RecordedAt), period(v.SupersededAt))
+				if i, ok := customer.PersonOf(v.Info); ok {
+					fmt.Fprintf(&b, "      %s %s, SSN %s\n", i.GivenName, i.FamilyName, i.SSN)
+				}
+				if i, ok := v.Info.(*customer.OrganizationInfo); ok {
+					fmt.Fprintf(&b, "      %s %s, legal ID %s\n", i.Name, i.Form, i.LeagalID)
+				}
+			}
+		}
+
+		if len(c.Audit) > 0 {
+			fmt.Fprintf(&b, "  Audit trail:\n")
+			for _, e := range c.Audit {
+				fmt.Fprintf(&b, "    %s  %s\n", e.Time.Format(time.RFC3339), e.Op)
+			}
+		}
+	}
+
+	return b.String()
+}
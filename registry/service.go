@@ -2,6 +2,7 @@ package registry
 
 import (
 	"context"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/go-playground/validator/v10"
@@ -13,53 +14,243 @@ var (
 	ErrValidation = errors.New("Input validation failed")
 	ErrExpected   = errors.New("Expected error")
 	ErrUnexpected = errors.New("Unexpected error")
+	ErrLegalHold  = errors.New("Erasure deferred by legal hold")
+	ErrConflict   = errors.New("Unique data conflict")
+	ErrPermission = errors.New("Permission denied")
 )
 
-func NewService(r Repo) Service {
+// permissions required by the service operations
+const (
+	PermRead  = "registry:read"
+	PermWrite = "registry:write"
+	PermAdmin = "registry:admin"
+	// PermConvert is required to change the type of a customer
+	PermConvert = "registry:convert"
+)
+
+func NewService(r Repo, opts ...Option) Service {
+
+	svc := &service{repo: r, validate: customer.NewValidator(), ages: customer.DefaultAgeRules, mergeGrace: DefaultMergeGracePeriod}
+
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	return svc
+}
+
+type Option func(*service)
+
+// WithRiskScorer enables risk rating of customers on New and UpdateInfo
+func WithRiskScorer(rs RiskScorer) Option {
+	return func(svc *service) {
+		svc.risk = rs
+	}
+}
+
+// WithLegalHold defers Erase of customers under legal hold
+func WithLegalHold(lh LegalHold) Option {
+	return func(svc *service) {
+		svc.hold = lh
+	}
+}
 
-	return &service{r, customer.NewValidator()}
+// WithAuthorizer enforces permissions on every operation
+func WithAuthorizer(a Authorizer) Option {
+	return func(svc *service) {
+		svc.authz = a
+	}
+}
+
+// WithAuditLog records every change made through the service
+func WithAuditLog(al AuditLog) Option {
+	return func(svc *service) {
+		svc.audit = al
+	}
+}
+
+// WithNameCasing sets how names are cased when info is normalised, names are kept as entered
+// by default
+func WithNameCasing(c customer.Casing) Option {
+	return func(svc *service) {
+		svc.casing = c
+	}
+}
+
+// WithAgeRules replaces DefaultAgeRules, profiles must be compiled with the same rules
+func WithAgeRules(r customer.AgeRules) Option {
+	return func(svc *service) {
+		svc.ages = r
+		svc.validate = customer.NewValidatorWithAgeRules(r)
+	}
+}
+
+// WithPurgers registers indices that must forget a customer on Erase
+func WithPurgers(ps ...Purger) Option {
+	return func(svc *service) {
+		svc.purgers = append(svc.purgers, ps...)
+	}
 }
 
 type Service interface {
 	Get(ctx context.Context, id uint32) (*customer.Customer, error)
 	New(ctx context.Context, i customer.Info) (*customer.Customer, error)
 	UpdateInfo(ctx context.Context, id uint32, i customer.Info) (*customer.Customer, error)
+	// PatchInfo updates only the fields of the info named by their JSON names
+	PatchInfo(ctx context.Context, id uint32, i customer.Info, fields []string) (*customer.Customer, error)
 	SetState(ctx context.Context, id uint32, s customer.State) error
+	Erase(ctx context.Context, id uint32) error
+	SubjectAccessReport(ctx context.Context, ssn string) (*SubjectAccessReport, error)
+	List(ctx context.Context, after uint32, limit int) ([]*customer.Customer, error)
+	Search(ctx context.Context, query string, limit int) ([]*customer.Customer, error)
+	BatchGet(ctx context.Context, ids []uint32) ([]BatchResult, error)
+	BatchSetState(ctx context.Context, updates []StateUpdate) ([]BatchResult, error)
+	// GetAsOf returns the info valid at validTime as known at recordedTime
+	GetAsOf(ctx context.Context, id uint32, validTime, recordedTime time.Time) (*InfoVersion, error)
+	// CorrectInfo records info for a past or a still valid period without forgetting what was
+	// known before
+	CorrectInfo(ctx context.Context, id uint32, i customer.Info, validFrom, validTo time.Time) (*customer.Customer, error)
+	// ConvertType replaces the info of a prospect with info of another type
+	ConvertType(ctx context.Context, id uint32, i customer.Info) (*customer.Customer, error)
+	// Merge folds the source customer into the target and returns the target
+	Merge(ctx context.Context, sourceID, targetID uint32) (*customer.Customer, error)
+	// Unmerge restores a merged customer within the merge grace period
+	Unmerge(ctx context.Context, id uint32) (*customer.Customer, error)
+	// FindDuplicates ranks probable duplicate customers by score
+	FindDuplicates(ctx context.Context, minScore float64, limit int) ([]Duplicate, error)
 }
 
 type Repo interface {
 	Get(ctx context.Context, id uint32) (*customer.Customer, error)
 	Insert(ctx context.Context, c *customer.Customer) error
+	// Update stores c, redirects of merged customers are exempt from uniqueness
 	Update(ctx context.Context, c *customer.Customer) error
+	// BatchGet returns customers in the order of ids, nil for IDs not found
+	BatchGet(ctx context.Context, ids []uint32) ([]*customer.Customer, error)
+	// BatchUpdate updates customers at once and returns an error per customer
+	BatchUpdate(ctx context.Context, cs []*customer.Customer) ([]error, error)
+	// FindBySSN returns the customers with SSN, redirects of merged customers excluded
+	FindBySSN(ctx context.Context, ssn string) ([]*customer.Customer, error)
+	// FindMergedBySSN returns the redirects of merged customers with SSN, they keep their
+	// info for Unmerge
+	FindMergedBySSN(ctx context.Context, ssn string) ([]*customer.Customer, error)
+	// List returns at most limit customers with ID greater than after, ordered by ID
+	List(ctx context.Context, after uint32, limit int) ([]*customer.Customer, error)
+	// Search matches query against names and exactly against SSN, legal ID and business ID
+	Search(ctx context.Context, query string, limit int) ([]*customer.Customer, error)
+}
+
+type RiskScorer interface {
+	Score(ctx context.Context, c *customer.Customer) (customer.Risk, error)
+}
+
+type Authorizer interface {
+	Authorize(ctx context.Context, perm string) error
+}
+
+// LegalHold returns the time until which customer data must be retained, zero time if none
+type LegalHold interface {
+	Until(ctx context.Context, c *customer.Customer) (time.Time, error)
+}
+
+// Purger is implemented by audit and search indices holding plain customer values
+type Purger interface {
+	Purge(ctx context.Context, id uint32) error
 }
 
 type service struct {
 	repo     Repo
 	validate *validator.Validate
+	risk     RiskScorer
+	hold     LegalHold
+	purgers  []Purger
+	audit    AuditLog
+	authz    Authorizer
+	casing   customer.Casing
+	profiles Profiles
+	ages     customer.AgeRules
+
+	idempotency IdempotencyStore
+	history     InfoHistory
+	mergeGrace  time.Duration
+	duplicates  DuplicateFinder
+
+	locks customerLocks
 }
 
 func (svc *service) Get(ctx context.Context, id uint32) (*customer.Customer, error) {
 	const op string = "registry.Service.Get"
 
+	if err := svc.authorize(ctx, PermRead); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrPermission)
+	}
+
 	c, err := svc.repo.Get(ctx, id)
 	if err != nil {
 		return nil, errors.Mark(errors.Wrap(err, op), ErrNotFound)
 	}
 
+	if c, err = svc.follow(ctx, c); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+	}
+
 	return c, nil
 }
 
 func (svc *service) New(ctx context.Context, i customer.Info) (*customer.Customer, error) {
 	const op string = "registry.Service.New"
 
-	if err := svc.validate.Struct(i); err != nil {
+	if err := svc.authorize(ctx, PermWrite); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrPermission)
+	}
+
+	ref, err := svc.check(ctx, i)
+	if err != nil {
 		return nil, errors.Mark(errors.Wrap(err, op), ErrValidation)
 	}
 
+	c, key, err := svc.replay(ctx, i)
+	if err != nil || c != nil {
+		return c, errors.Wrap(err, op)
+	}
+
+	c, err = svc.insert(ctx, i, ref)
+	if err != nil {
+		if key != "" {
+			if rerr := svc.idempotency.Release(ctx, key); rerr != nil {
+				err = errors.WithSecondaryError(err, rerr)
+			}
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	if key != "" {
+		if err := svc.idempotency.Complete(ctx, key, c.ID); err != nil {
+			return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+		}
+	}
+
+	return c, nil
+}
+
+func (svc *service) insert(ctx context.Context, i customer.Info, ref customer.ProfileRef) (*customer.Customer, error) {
 	c := customer.NewWithRandomID(i)
+	c.Profile = ref
+
+	if err := svc.score(ctx, c); err != nil {
+		return nil, errors.Mark(err, ErrUnexpected)
+	}
 
 	if err := svc.repo.Insert(ctx, c); err != nil {
-		return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+		return nil, errors.Mark(err, ErrUnexpected)
+	}
+
+	if err := svc.recordVersion(ctx, c.ID, nil, newVersion(i)); err != nil {
+		return nil, errors.Mark(err, ErrUnexpected)
+	}
+
+	if err := svc.record(ctx, AuditEntry{CustomerID: c.ID, Op: OpNew, To: c.State}); err != nil {
+		return nil, errors.Mark(err, ErrUnexpected)
 	}
 
 	return c, nil
@@ -68,21 +259,105 @@ func (svc *service) New(ctx context.Context, i customer.Info) (*customer.Custome
 func (svc *service) UpdateInfo(ctx context.Context, id uint32, i customer.Info) (*customer.Customer, error) {
 	const op string = "registry.Service.UpdateInfo"
 
-	if err := svc.validate.Struct(i); err != nil {
+	if err := svc.authorize(ctx, PermWrite); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrPermission)
+	}
+
+	ref, err := svc.check(ctx, i)
+	if err != nil {
 		return nil, errors.Mark(errors.Wrap(err, op), ErrValidation)
 	}
 
+	c, err := svc.changeInfo(ctx, id, OpUpdateInfo, func(c *customer.Customer) (InfoVersion, error) {
+		if err := c.UpdateInfo(i); err != nil {
+			return InfoVersion{}, errors.Mark(err, ErrExpected)
+		}
+		c.Profile = ref
+		return newVersion(i), nil
+	})
+
+	return c, errors.Wrap(err, op)
+}
+
+func (svc *service) PatchInfo(ctx context.Context, id uint32, i customer.Info, fields []string) (*customer.Customer, error) {
+	const op string = "registry.Service.PatchInfo"
+
+	if err := svc.authorize(ctx, PermWrite); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrPermission)
+	}
+
+	if err := svc.validate.Var(fields, "min=1"); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrValidation)
+	}
+
+	c, err := svc.changeInfo(ctx, id, OpUpdateInfo, func(c *customer.Customer) (InfoVersion, error) {
+		merged, err := c.MergeInfo(i, fields)
+		if errors.Is(err, customer.ErrUnknownField) {
+			return InfoVersion{}, errors.Mark(err, ErrValidation)
+		}
+		if err != nil {
+			return InfoVersion{}, errors.Mark(err, ErrExpected)
+		}
+
+		ref, err := svc.check(ctx, merged)
+		if err != nil {
+			return InfoVersion{}, errors.Mark(err, ErrValidation)
+		}
+
+		if err := c.UpdateInfo(merged); err != nil {
+			return InfoVersion{}, errors.Mark(err, ErrExpected)
+		}
+		c.Profile = ref
+		return newVersion(merged), nil
+	})
+
+	return c, errors.Wrap(err, op)
+}
+
+// infoChange changes the info of customer c and returns the version of the info to record
+type infoChange func(c *customer.Customer) (InfoVersion, error)
+
+// changeInfo applies change to customer id, scores and stores the customer and records the
+// new version and an audit entry of auditOp. A version no longer valid now only corrects the
+// history and leaves the customer as it was. The customer is locked from the read to the
+// write back. Returned errors are marked.
+func (svc *service) changeInfo(ctx context.Context, id uint32, auditOp string, change infoChange) (*customer.Customer, error) {
+	defer svc.locks.lock(ctx, id)()
+
 	c, err := svc.repo.Get(ctx, id)
 	if err != nil {
-		return nil, errors.Mark(errors.Wrap(err, op), ErrNotFound)
+		return nil, errors.Mark(err, ErrNotFound)
 	}
 
-	if err := c.UpdateInfo(i); err != nil {
-		return nil, errors.Mark(errors.Wrap(err, op), ErrExpected)
+	prev, prevProfile := c.Info, c.Profile
+
+	v, err := change(c)
+	if err != nil {
+		return nil, err
 	}
 
-	if err = svc.repo.Update(ctx, c); err != nil {
-		return nil, errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+	if v.ValidTo.IsZero() || v.ValidTo.After(v.RecordedAt) {
+		if err := svc.score(ctx, c); err != nil {
+			return nil, errors.Mark(err, ErrUnexpected)
+		}
+
+		if err := svc.repo.Update(ctx, c); err != nil {
+			return nil, errors.Mark(err, ErrUnexpected)
+		}
+	} else {
+		c.Info, c.Profile = prev, prevProfile
+	}
+
+	if err := svc.recordVersion(ctx, c.ID, prev, v); err != nil {
+		return nil, errors.Mark(err, ErrUnexpected)
+	}
+
+	e := AuditEntry{CustomerID: c.ID, Op: auditOp}
+	if from, to := prev.Type(), v.Info.Type(); from != to {
+		e.FromType, e.ToType = from, to
+	}
+	if err := svc.record(ctx, e); err != nil {
+		return nil, errors.Mark(err, ErrUnexpected)
 	}
 
 	return c, nil
@@ -91,16 +366,123 @@ func (svc *service) UpdateInfo(ctx context.Context, id uint32, i customer.Info)
 func (svc *service) SetState(ctx context.Context, id uint32, s customer.State) error {
 	const op string = "registry.Service.SetState"
 
+	if err := svc.authorize(ctx, PermAdmin); err != nil {
+		return errors.Mark(errors.Wrap(err, op), ErrPermission)
+	}
+
 	if err := svc.validate.Var(s, "min=1,max=3"); err != nil {
 		return errors.Mark(errors.Wrap(err, op), ErrValidation)
 	}
 
+	defer svc.locks.lock(ctx, id)()
+
 	c, err := svc.repo.Get(ctx, id)
 	if err != nil {
 		return errors.Mark(errors.Wrap(err, op), ErrNotFound)
 	}
 
-	c.State = s
+	from := c.State
+
+	if err := c.SetState(s, svc.ages); err != nil {
+		return errors.Mark(errors.Wrap(err, op), ErrExpected)
+	}
+
+	if err := svc.repo.Update(ctx, c); err != nil {
+		return errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+	}
+
+	return errors.Mark(errors.Wrap(svc.record(ctx, AuditEntry{CustomerID: id, Op: OpSetState, From: from, To: s}), op), ErrUnexpected)
+}
+
+// Erase forgets the personal data of a customer. When a legal hold applies the request is
+// recorded on the customer, ErrLegalHold returned and Erase must be called again once the
+// hold has expired.
+func (svc *service) Erase(ctx context.Context, id uint32) error {
+	const op string = "registry.Service.Erase"
+
+	if err := svc.authorize(ctx, PermAdmin); err != nil {
+		return errors.Mark(errors.Wrap(err, op), ErrPermission)
+	}
+
+	defer svc.locks.lock(ctx, id)()
+
+	c, err := svc.repo.Get(ctx, id)
+	if err != nil {
+		return errors.Mark(errors.Wrap(err, op), ErrNotFound)
+	}
+
+	if c.Erased {
+		return nil
+	}
+
+	if svc.hold != nil {
+		until, err := svc.hold.Until(ctx, c)
+		if err != nil {
+			return errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+		}
+
+		if until.After(time.Now()) {
+			c.ErasureDue = until
+			if err := svc.repo.Update(ctx, c); err != nil {
+				return errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+			}
+			return errors.Mark(errors.Wrapf(ErrLegalHold, "%s: held until %s", op, until.Format(time.RFC3339)), ErrExpected)
+		}
+	}
+
+	if err := c.Erase(); err != nil {
+		return errors.Mark(errors.Wrap(err, op), ErrExpected)
+	}
+
+	if err := svc.repo.Update(ctx, c); err != nil {
+		return errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+	}
+
+	for _, p := range svc.purgers {
+		if err := p.Purge(ctx, id); err != nil {
+			return errors.Mark(errors.Wrap(err, op), ErrUnexpected)
+		}
+	}
+
+	return errors.Mark(errors.Wrap(svc.record(ctx, AuditEntry{CustomerID: id, Op: OpErase}), op), ErrUnexpected)
+}
+
+// check normalises and validates i against the built-in rules and the profile applying to
+// the request, it returns the profile applied
+func (svc *service) check(ctx context.Context, i customer.Info) (customer.ProfileRef, error) {
+	if i == nil {
+		return customer.ProfileRef{}, svc.validate.Struct(i)
+	}
+
+	i.Normalise(svc.casing)
+
+	p := svc.profiles.selectFor(ctx, i)
+	if p == nil {
+		return customer.ProfileRef{}, svc.validate.Struct(i)
+	}
+
+	return p.Profile.Ref(), p.validate.Struct(i)
+}
+
+func (svc *service) authorize(ctx context.Context, perm string) error {
+	if svc.authz == nil {
+		return nil
+	}
+
+	return svc.authz.Authorize(ctx, perm)
+}
+
+func (svc *service) score(ctx context.Context, c *customer.Customer) error {
+	if svc.risk == nil {
+		return nil
+	}
+
+	r, err := svc.risk.Score(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	c.Risk = r
 
-	return errors.Mark(errors.Wrap(svc.repo.Update(ctx, c), op), ErrUnexpected)
+	return nil
 }
@@ -2,13 +2,20 @@ package registry_test
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/auth"
 	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/dedup"
 	"github.com/nacobas/customer/registry"
 	"github.com/nacobas/customer/repo/inmem"
+	"github.com/nacobas/customer/tenant"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -61,6 +68,12 @@ func TestNew(t *testing.T) {
 			want: nil,
 			err:  registry.ErrValidation,
 		},
+		{
+			desc: "duplicate SSN",
+			info: testPerson(t),
+			want: nil,
+			err:  registry.ErrConflict,
+		},
 	}
 	for i := range testCases {
 		tC := testCases[i]
@@ -272,6 +285,1050 @@ func TestSetState(t *testing.T) {
 	}
 }
 
+func TestErase(t *testing.T) {
+	t.Parallel()
+
+	repo := inmem.NewRepoWithSeed(append(seed(t), customer.Customer{ID: 4, State: 3, Info: testPerson(t)}))
+
+	holds := registry.NewLegalHolds()
+	holds.Place(context.Background(), 4, time.Now().Add(time.Hour))
+
+	svc := registry.NewService(repo, registry.WithLegalHold(holds))
+
+	testCases := []struct {
+		desc string
+		id   uint32
+		err  error
+	}{
+		{
+			desc: "erase person",
+			id:   1,
+			err:  nil,
+		},
+		{
+			desc: "erase is idempotent",
+			id:   1,
+			err:  nil,
+		},
+		{
+			desc: "organizations can not be erased",
+			id:   2,
+			err:  customer.ErrNotErasable,
+		},
+		{
+			desc: "not found",
+			id:   3,
+			err:  registry.ErrNotFound,
+		},
+		{
+			desc: "legal hold defers erasure",
+			id:   4,
+			err:  registry.ErrLegalHold,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			err := svc.Erase(context.Background(), tC.id)
+
+			assert.Truef(t, errors.Is(err, tC.err), "Expecting %v , got: %v", tC.err, err)
+		})
+	}
+
+	erased, err := svc.Get(context.Background(), 1)
+	assert.Nil(t, err, "erased customer should still be found")
+	assert.True(t, erased.Erased, "customer should be marked erased")
+	assert.NotContains(t, erased.Info.(*customer.PersonInfo).SSN, "SSN", "SSN should be tombstoned")
+
+	_, err = svc.UpdateInfo(context.Background(), 1, testPerson(t))
+	assert.True(t, errors.Is(err, customer.ErrErased), "erased customer should not be updated")
+
+	held, err := svc.Get(context.Background(), 4)
+	assert.Nil(t, err, "error should be nil")
+	assert.False(t, held.Erased, "held customer should not be erased")
+	assert.False(t, held.ErasureDue.IsZero(), "deferred erasure should be recorded")
+
+	holds.Release(context.Background(), 4)
+	assert.Nil(t, svc.Erase(context.Background(), 4), "erasure should proceed once hold is released")
+}
+
+func TestSubjectAccessReport(t *testing.T) {
+	t.Parallel()
+
+	svc := registry.NewService(inmem.NewRepoWithSeed(seed(t)), registry.WithAuditLog(inmem.NewAuditLog()),
+		registry.WithInfoHistory(inmem.NewInfoHistory()))
+
+	info := func(familyName string) *customer.PersonInfo {
+		return &customer.PersonInfo{
+			GivenName:   "given-name",
+			FamilyName:  familyName,
+			SSN:         "SAR-SSN",
+			DateOfBirth: parseDate(t, "1970-01-01"),
+			Citizenship: "US",
+		}
+	}
+
+	c, err := svc.New(context.Background(), info("family-name"))
+	assert.Nil(t, err, "error should be nil")
+	assert.Nil(t, svc.SetState(context.Background(), c.ID, customer.Active), "error should be nil")
+	_, err = svc.UpdateInfo(context.Background(), c.ID, info("new-name"))
+	assert.Nil(t, err, "error should be nil")
+
+	r, err := svc.SubjectAccessReport(context.Background(), "SAR-SSN")
+	assert.Nil(t, err, "error should be nil")
+	assert.Len(t, r.Customers, 1, "one customer should be reported")
+	assert.Equal(t, []registry.StateChange{
+		{Time: r.Customers[0].Audit[0].Time, To: "Prospect"},
+		{Time: r.Customers[0].Audit[1].Time, From: "Prospect", To: "Active"},
+	}, r.Customers[0].StateHistory, "state history should be derived from audit entries")
+
+	doc, err := json.Marshal(r)
+	assert.Nil(t, err, "report should marshal")
+	assert.Contains(t, string(doc), `"ssn":"SAR-SSN"`, "JSON document should hold the info")
+	assert.Contains(t, r.Text(), "Prospect -> Active", "text should render state history")
+
+	if assert.Len(t, r.Customers[0].InfoHistory, 3, "info history should be reported") {
+		assert.Equal(t, "family-name", r.Customers[0].InfoHistory[0].Info.(*customer.PersonInfo).FamilyName, "earlier info")
+	}
+	assert.Contains(t, string(doc), `"info_history"`, "JSON document should hold the info history")
+	assert.Contains(t, r.Text(), "given-name family-name, SSN SAR-SSN", "text should render earlier info")
+
+	_, err = svc.SubjectAccessReport(context.Background(), "unknown")
+	assert.True(t, errors.Is(err, registry.ErrNotFound), "unknown SSN should not be found")
+}
+
+func TestAuthorization(t *testing.T) {
+	t.Parallel()
+
+	rbac := auth.NewRBAC(map[string][]string{
+		"agent": {registry.PermRead, registry.PermWrite},
+		"admin": {registry.PermRead, registry.PermWrite, registry.PermAdmin},
+	})
+
+	svc := registry.NewService(inmem.NewRepoWithSeed(seed(t)), registry.WithAuthorizer(rbac))
+
+	agent := auth.NewContext(context.Background(), &auth.Principal{Subject: "agent", Roles: []string{"agent"}})
+	admin := auth.NewContext(context.Background(), &auth.Principal{Subject: "admin", Roles: []string{"admin"}})
+
+	_, err := svc.Get(context.Background(), 1)
+	assert.True(t, errors.Is(err, auth.ErrUnauthenticated), "anonymous callers should be rejected")
+
+	_, err = svc.Get(agent, 1)
+	assert.Nil(t, err, "agent should read")
+
+	err = svc.SetState(agent, 1, customer.Active)
+	assert.True(t, errors.Is(err, registry.ErrPermission), "SetState should require registry:admin")
+
+	err = svc.SetState(admin, 1, customer.Active)
+	assert.Nil(t, err, "admin should set state")
+}
+
+func TestTenantIsolation(t *testing.T) {
+	t.Parallel()
+
+	svc := registry.NewService(inmem.NewRepo())
+
+	brandA := tenant.NewContext(context.Background(), "brand-a")
+	brandB := tenant.NewContext(context.Background(), "brand-b")
+
+	a, err := svc.New(brandA, testPerson(t))
+	assert.Nil(t, err, "error should be nil")
+
+	b, err := svc.New(brandB, testPerson(t))
+	assert.Nil(t, err, "same SSN should be allowed in another tenant")
+
+	_, err = svc.New(brandA, testPerson(t))
+	assert.True(t, errors.Is(err, registry.ErrConflict), "SSN should be unique within a tenant")
+
+	testCases := []struct {
+		desc string
+		ctx  context.Context
+		id   uint32
+		err  error
+	}{
+		{
+			desc: "own customer",
+			ctx:  brandA,
+			id:   a.ID,
+			err:  nil,
+		},
+		{
+			desc: "other tenant's customer",
+			ctx:  brandA,
+			id:   b.ID,
+			err:  registry.ErrNotFound,
+		},
+		{
+			desc: "default tenant does not see tenants",
+			ctx:  context.Background(),
+			id:   a.ID,
+			err:  registry.ErrNotFound,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := svc.Get(tC.ctx, tC.id)
+			assert.Truef(t, errors.Is(err, tC.err), "Expecting %v , got: %v", tC.err, err)
+
+			_, err = svc.UpdateInfo(tC.ctx, tC.id, testPerson(t))
+			assert.Truef(t, errors.Is(err, tC.err), "Expecting %v , got: %v", tC.err, err)
+
+			err = svc.SetState(tC.ctx, tC.id, customer.Active)
+			assert.Truef(t, errors.Is(err, tC.err), "Expecting %v , got: %v", tC.err, err)
+		})
+	}
+}
+
+func TestListAndSearch(t *testing.T) {
+	t.Parallel()
+
+	svc := registry.NewService(inmem.NewRepoWithSeed(seed(t)))
+
+	page, err := svc.List(context.Background(), 0, 1)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, []uint32{1}, ids(page), "first page")
+
+	page, err = svc.List(context.Background(), page[0].ID, 1)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, []uint32{2}, ids(page), "second page")
+
+	page, err = svc.List(context.Background(), page[0].ID, 1)
+	assert.Nil(t, err, "error should be nil")
+	assert.Empty(t, page, "no more pages")
+
+	_, err = svc.List(context.Background(), 0, registry.MaxLimit+1)
+	assert.True(t, errors.Is(err, registry.ErrValidation), "limit should be validated")
+
+	testCases := []struct {
+		desc  string
+		query string
+		want  []uint32
+		err   error
+	}{
+		{
+			desc:  "person name",
+			query: "GIVEN-name fam",
+			want:  []uint32{1},
+		},
+		{
+			desc:  "org name",
+			query: "org",
+			want:  []uint32{2},
+		},
+		{
+			desc:  "SSN",
+			query: "SSN",
+			want:  []uint32{1},
+		},
+		{
+			desc:  "legal ID",
+			query: "legal-id",
+			want:  []uint32{2},
+		},
+		{
+			desc:  "no match",
+			query: "nobody",
+			want:  []uint32{},
+		},
+		{
+			desc:  "too short",
+			query: "o",
+			err:   registry.ErrValidation,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := svc.Search(context.Background(), tC.query, 0)
+
+			if tC.err == nil {
+				assert.Nil(t, err, "error should be nil")
+				assert.Equal(t, tC.want, ids(got), "search result")
+			} else {
+				assert.True(t, errors.Is(err, tC.err), "Expected error should be found in the chain")
+			}
+		})
+	}
+}
+
+func TestNormalisation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	svc := registry.NewService(inmem.NewRepoWithSeed(seed(t)), registry.WithNameCasing(customer.TitleCase))
+
+	pi := testPerson(t)
+	pi.GivenName = "  a\u0308ino "
+	pi.FamilyName = "VIRTANEN"
+	pi.SSN = "ssn-2"
+
+	c, err := svc.New(ctx, pi)
+	assert.Nil(t, err, "decomposed name should validate once composed")
+
+	got := c.Info.(*customer.PersonInfo)
+	assert.Equal(t, "\u00c4ino", got.GivenName, "display form in NFC, trimmed and title cased")
+	assert.Equal(t, "Virtanen", got.FamilyName, "display form title cased")
+	assert.Equal(t, "ssn-2", got.SSN, "identifiers keep their case")
+
+	found, err := svc.Search(ctx, "A\u0308INO  virtanen", 0)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, []uint32{c.ID}, ids(found), "search compares canonical names")
+
+	found, err = svc.Search(ctx, "SSN-2", 0)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, []uint32{c.ID}, ids(found), "search compares canonical identifiers")
+
+	dup := testPerson(t)
+	dup.SSN = " ssn "
+	_, err = svc.New(ctx, dup)
+	assert.True(t, errors.Is(err, registry.ErrConflict), "uniqueness compares canonical identifiers")
+}
+
+func TestInternationalNames(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	svc := registry.NewService(inmem.NewRepo())
+
+	pi := testPerson(t)
+	pi.GivenName, pi.MiddleNames, pi.FamilyNamePrefix, pi.FamilyName = "Séamus", []string{"Anne Marie"}, "van der", "O'Brien"
+
+	c, err := svc.New(ctx, pi)
+	assert.Nil(t, err, "error should be nil")
+
+	for _, q := range []string{"seamus anne", "van der obrien", "Séamus Anne Marie van der O’Brien"} {
+		found, err := svc.Search(ctx, q, 0)
+		assert.Nil(t, err, "error should be nil")
+		assert.Equal(t, []uint32{c.ID}, ids(found), "search by "+q)
+	}
+
+	c, err = svc.PatchInfo(ctx, c.ID, &customer.PersonInfo{MiddleNames: []string{"Иван"}}, []string{"middle_names"})
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, []string{"Иван"}, c.Info.(*customer.PersonInfo).MiddleNames, "middle names patched")
+
+	found, err := svc.Search(ctx, "ivan", 0)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, []uint32{c.ID}, ids(found), "non-Latin names found by transliteration")
+}
+
+func TestSoleTrader(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	svc := registry.NewService(inmem.NewRepoWithSeed(seed(t)))
+
+	c, err := svc.New(ctx, testSoleTrader(t))
+	assert.Nil(t, err, "a private customer may also be a sole trader")
+
+	_, err = svc.New(ctx, &customer.SoleTraderInfo{})
+	assert.True(t, errors.Is(err, registry.ErrValidation), "sole trader info should be validated")
+
+	other := testSoleTrader(t)
+	other.SSN = "SSN-2"
+	other.BusinessID = "legal-id"
+	_, err = svc.New(ctx, other)
+	assert.True(t, errors.Is(err, registry.ErrConflict), "business IDs share the register with legal IDs")
+
+	r, err := svc.SubjectAccessReport(ctx, "SSN")
+	assert.Nil(t, err, "error should be nil")
+	assert.Len(t, r.Customers, 2, "report covers the private customer and the sole trader")
+	assert.Contains(t, r.Text(), "Business ID:", "report shows the business")
+
+	found, err := svc.Search(ctx, "business-id", 0)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, []uint32{c.ID}, ids(found), "search by business ID")
+
+	found, err = svc.Search(ctx, "trade", 0)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, []uint32{c.ID}, ids(found), "search by trade name")
+
+	_, err = svc.UpdateInfo(ctx, c.ID, testOrg(t))
+	assert.True(t, errors.Is(err, customer.ErrTypeNotEqual), "sole trader can not become an organization")
+
+	stopped := testPerson(t)
+	stopped.SSN = "SSN-3"
+	_, err = svc.UpdateInfo(ctx, c.ID, stopped)
+	assert.True(t, errors.Is(err, customer.ErrTypeNotEqual), "person must stay the same")
+
+	_, err = svc.UpdateInfo(ctx, c.ID, testPerson(t))
+	assert.True(t, errors.Is(err, registry.ErrConflict), "stopping trading would duplicate the private customer")
+
+	_, err = svc.UpdateInfo(ctx, 1, testSoleTrader(t))
+	assert.True(t, errors.Is(err, registry.ErrConflict), "a person is one sole trader")
+}
+
+func TestConvertType(t *testing.T) {
+	t.Parallel()
+
+	audit := inmem.NewAuditLog()
+	rbac := auth.NewRBAC(map[string][]string{
+		"agent":   {registry.PermRead, registry.PermWrite},
+		"officer": {registry.PermRead, registry.PermWrite, registry.PermConvert},
+	})
+	svc := registry.NewService(inmem.NewRepoWithSeed(seed(t)), registry.WithAuthorizer(rbac), registry.WithAuditLog(audit))
+
+	agent := auth.NewContext(context.Background(), &auth.Principal{Subject: "agent", Roles: []string{"agent"}})
+	officer := auth.NewContext(context.Background(), &auth.Principal{Subject: "officer", Roles: []string{"officer"}})
+
+	org := testOrg(t)
+	org.LeagalID = "legal-id-2"
+
+	_, err := svc.ConvertType(agent, 1, org)
+	assert.True(t, errors.Is(err, registry.ErrPermission), "ConvertType should require registry:convert")
+
+	_, err = svc.ConvertType(officer, 1, &customer.OrganizationInfo{})
+	assert.True(t, errors.Is(err, registry.ErrValidation), "new info should be validated")
+
+	_, err = svc.ConvertType(officer, 1, testOrg(t))
+	assert.True(t, errors.Is(err, registry.ErrConflict), "new info should be unique")
+
+	_, err = svc.ConvertType(officer, 2, testPerson(t))
+	assert.True(t, errors.Is(err, customer.ErrNotProspect), "only prospects should be converted")
+
+	_, err = svc.ConvertType(officer, 99, org)
+	assert.True(t, errors.Is(err, registry.ErrNotFound), "missing customer")
+
+	c, err := svc.ConvertType(officer, 1, org)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, uint32(1), c.ID, "customer should keep its ID")
+	assert.Equal(t, customer.Organization, c.Type(), "customer should be an organization")
+
+	found, err := svc.Search(officer, "SSN", 0)
+	assert.Nil(t, err, "error should be nil")
+	assert.Empty(t, found, "old identifiers should not be found")
+
+	_, err = svc.ConvertType(officer, 1, org)
+	assert.True(t, errors.Is(err, customer.ErrSameType), "type should change")
+
+	entries, err := audit.Entries(officer, 1)
+	assert.Nil(t, err, "error should be nil")
+	assert.Len(t, entries, 1, "conversion should be audited")
+	assert.Equal(t, registry.OpConvertType, entries[0].Op, "audit op")
+	assert.Equal(t, customer.Private, entries[0].FromType, "audit should name the old type")
+	assert.Equal(t, customer.Organization, entries[0].ToType, "audit should name the new type")
+}
+
+func TestValidationProfiles(t *testing.T) {
+	t.Parallel()
+
+	profiles, err := registry.CompileProfiles([]registry.ProfileBinding{
+		{
+			Profile: customer.Profile{Name: "bank", Version: 3, MinAge: 18},
+			Tenants: []string{"bank"},
+		},
+		{
+			Profile:   customer.Profile{Name: "us", Version: 1, Required: []string{"middle_names"}},
+			Countries: []string{"US"},
+		},
+	}, customer.DefaultAgeRules)
+	assert.Nil(t, err, "error should be nil")
+
+	_, err = registry.CompileProfiles([]registry.ProfileBinding{{Profile: customer.Profile{Name: "us"}}, {Profile: customer.Profile{Name: "us"}}}, customer.DefaultAgeRules)
+	assert.True(t, errors.Is(err, registry.ErrDuplicateProfile), "profile names should be unique")
+
+	svc := registry.NewService(inmem.NewRepoWithSeed(seed(t)), registry.WithValidationProfiles(profiles))
+
+	ctx := context.Background()
+	bank := tenant.NewContext(ctx, "bank")
+
+	minor := testPerson(t)
+	minor.SSN = "SSN-2"
+	minor.DateOfBirth = date.Date{Time: time.Now().AddDate(-10, 0, 0)}
+
+	_, err = svc.New(bank, minor)
+	assert.True(t, errors.Is(err, registry.ErrValidation), "tenant profile should apply")
+
+	minor.MiddleNames = []string{"middle-name"}
+	c, err := svc.New(ctx, minor)
+	assert.Nil(t, err, "country profile should apply without a tenant profile")
+	assert.Equal(t, customer.ProfileRef{Name: "us", Version: 1}, c.Profile, "profile should be recorded")
+
+	_, err = svc.UpdateInfo(ctx, 1, testPerson(t))
+	assert.True(t, errors.Is(err, registry.ErrValidation), "country profile should apply on update")
+
+	adult := testPerson(t)
+	adult.SSN = "SSN-3"
+	c, err = svc.New(bank, adult)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, customer.ProfileRef{Name: "bank", Version: 3}, c.Profile, "tenant profile should be recorded")
+
+	org := testOrg(t)
+	org.RegistrationCountry = "FI"
+	c, err = svc.UpdateInfo(ctx, 2, org)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, customer.ProfileRef{}, c.Profile, "built-in rules only")
+}
+
+func TestActivationAge(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	minor := testPerson(t)
+	minor.SSN = "SSN-2"
+	minor.DateOfBirth = date.Date{Time: time.Now().AddDate(-17, 0, 0)}
+
+	repo := inmem.NewRepoWithSeed(append(seed(t), customer.Customer{ID: 3, State: 1, Info: minor}))
+	svc := registry.NewService(repo)
+
+	err := svc.SetState(ctx, 3, customer.Active)
+	assert.True(t, errors.Is(err, customer.ErrTooYoung), "minors should not become active")
+	assert.True(t, errors.Is(err, registry.ErrExpected), "age is a precondition")
+
+	rs, err := svc.BatchSetState(ctx, []registry.StateUpdate{{ID: 1, State: customer.Active}, {ID: 3, State: customer.Active}})
+	assert.Nil(t, err, "error should be nil")
+	assert.Nil(t, rs[0].Err, "adult should become active")
+	assert.True(t, errors.Is(rs[1].Err, customer.ErrTooYoung), "minor should not become active in a batch")
+
+	c, err := svc.Get(ctx, 3)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, customer.Prospect, c.State, "state should not change")
+
+	lenient := registry.NewService(repo, registry.WithAgeRules(customer.AgeRules{MinActiveAge: 16}))
+	assert.Nil(t, lenient.SetState(ctx, 3, customer.Active), "configured minimum age")
+
+	old := testPerson(t)
+	old.DateOfBirth = parseDate(t, "1850-01-01")
+	_, err = svc.UpdateInfo(ctx, 1, old)
+	assert.True(t, errors.Is(err, registry.ErrValidation), "date of birth too long ago")
+
+	_, err = lenient.UpdateInfo(ctx, 1, old)
+	assert.Nil(t, err, "maximum age disabled")
+}
+
+func ids(cs []*customer.Customer) []uint32 {
+	ids := []uint32{}
+	for _, c := range cs {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
+
+type riskByCountry map[string]customer.RiskRating
+
+func (rs riskByCountry) Score(ctx context.Context, c *customer.Customer) (customer.Risk, error) {
+	r := rs[c.Info.(*customer.PersonInfo).Citizenship]
+	return customer.Risk{Rating: r, Reasons: []string{"citizenship"}}, nil
+}
+
+func TestRiskRecalculation(t *testing.T) {
+	t.Parallel()
+
+	repo := inmem.NewRepo()
+
+	svc := registry.NewService(repo, registry.WithRiskScorer(riskByCountry{"US": customer.LowRisk, "KP": customer.HighRisk}))
+
+	c, err := svc.New(context.Background(), testPerson(t))
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, customer.LowRisk, c.Risk.Rating, "new customer should be rated")
+
+	i := testPerson(t)
+	i.Citizenship = "KP"
+
+	c, err = svc.UpdateInfo(context.Background(), c.ID, i)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, customer.HighRisk, c.Risk.Rating, "rating should be recalculated on UpdateInfo")
+
+	got, err := svc.Get(context.Background(), c.ID)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, c.Risk, got.Risk, "rating should be stored")
+}
+
+func TestIdempotency(t *testing.T) {
+	t.Parallel()
+
+	svc := registry.NewService(inmem.NewRepo(), registry.WithIdempotency(inmem.NewIdempotencyStore(time.Hour)))
+
+	ctx := registry.NewIdempotencyContext(context.Background(), "key-1")
+
+	first, err := svc.New(ctx, testPerson(t))
+	assert.Nil(t, err, "error should be nil")
+
+	retry, err := svc.New(ctx, testPerson(t))
+	assert.Nil(t, err, "retry should not conflict")
+	assert.Equal(t, first.ID, retry.ID, "retry should return the original customer")
+
+	person := testPerson(t)
+	person.SSN = "SSN-2"
+	survivor, err := svc.New(context.Background(), person)
+	assert.Nil(t, err, "error should be nil")
+	_, err = svc.Merge(context.Background(), first.ID, survivor.ID)
+	assert.Nil(t, err, "error should be nil")
+
+	retry, err = svc.New(ctx, testPerson(t))
+	assert.Nil(t, err, "retry after merge should not fail")
+	assert.Equal(t, survivor.ID, retry.ID, "retry should follow the merge to the survivor")
+
+	_, err = svc.New(ctx, testOrg(t))
+	assert.True(t, errors.Is(err, registry.ErrIdempotencyMismatch), "key reuse with another payload")
+	assert.True(t, errors.Is(err, registry.ErrValidation), "mismatch is a validation error")
+
+	other := tenant.NewContext(ctx, "brand-a")
+	c, err := svc.New(other, testPerson(t))
+	assert.Nil(t, err, "keys should be scoped to the tenant")
+	assert.NotEqual(t, first.ID, c.ID, "another tenant gets its own customer")
+
+	failed := registry.NewIdempotencyContext(context.Background(), "key-2")
+	_, err = svc.New(failed, person)
+	assert.True(t, errors.Is(err, registry.ErrConflict), "duplicate SSN")
+
+	_, err = svc.New(failed, testOrg(t))
+	assert.Nil(t, err, "key of a failed request should be released")
+}
+
+func TestPatchInfo(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	testCases := []struct {
+		desc   string
+		id     uint32
+		info   customer.Info
+		fields []string
+		want   customer.Info
+		err    error
+	}{
+		{
+			desc:   "patch masked person fields",
+			id:     1,
+			info:   &customer.PersonInfo{FamilyName: "new-name", Citizenship: "SE"},
+			fields: []string{"family_name"},
+			want: &customer.PersonInfo{
+				GivenName:   "given-name",
+				FamilyName:  "new-name",
+				SSN:         "SSN",
+				DateOfBirth: parseDate(t, "1970-01-01"),
+				Citizenship: "US"},
+		},
+		{
+			desc:   "merged result is validated",
+			id:     2,
+			info:   &customer.OrganizationInfo{RegistrationCountry: "XX"},
+			fields: []string{"registration_country"},
+			err:    registry.ErrValidation,
+		},
+		{
+			desc:   "unknown field",
+			id:     1,
+			info:   &customer.PersonInfo{},
+			fields: []string{"phone"},
+			err:    registry.ErrValidation,
+		},
+		{
+			desc:   "empty mask",
+			id:     1,
+			info:   &customer.PersonInfo{},
+			fields: nil,
+			err:    registry.ErrValidation,
+		},
+		{
+			desc:   "type mismatch",
+			id:     1,
+			info:   &customer.OrganizationInfo{},
+			fields: []string{"name"},
+			err:    registry.ErrExpected,
+		},
+		{
+			desc:   "not found",
+			id:     99,
+			info:   &customer.PersonInfo{},
+			fields: []string{"given_name"},
+			err:    registry.ErrNotFound,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			svc := registry.NewService(inmem.NewRepoWithSeed(seed(t)))
+
+			got, err := svc.PatchInfo(ctx, tC.id, tC.info, tC.fields)
+
+			if tC.err == nil {
+				assert.Nil(t, err, "error should be nil")
+				assert.Equal(t, tC.want, got.Info)
+			} else {
+				assert.Truef(t, errors.Is(err, tC.err), "Expecting %v , got: %v", tC.err, err)
+			}
+		})
+	}
+}
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	audit := inmem.NewAuditLog()
+	svc := registry.NewService(inmem.NewRepoWithSeed(seed(t)), registry.WithAuditLog(audit))
+
+	rs, err := svc.BatchGet(ctx, []uint32{2, 99, 1})
+	assert.Nil(t, err, "error should be nil")
+	assert.Len(t, rs, 3, "a result per ID")
+	assert.Equal(t, uint32(2), rs[0].Customer.ID, "results in the order of IDs")
+	assert.True(t, errors.Is(rs[1].Err, registry.ErrNotFound), "missing ID")
+	assert.Nil(t, rs[1].Customer, "missing ID has no customer")
+	assert.Equal(t, uint32(1), rs[2].Customer.ID, "results in the order of IDs")
+
+	_, err = svc.BatchGet(ctx, nil)
+	assert.True(t, errors.Is(err, registry.ErrValidation), "empty batch")
+
+	_, err = svc.BatchGet(ctx, make([]uint32, registry.MaxBatch+1))
+	assert.True(t, errors.Is(err, registry.ErrValidation), "batch too large")
+
+	rs, err = svc.BatchSetState(ctx, []registry.StateUpdate{
+		{ID: 1, State: customer.Passive},
+		{ID: 2, State: 9},
+		{ID: 99, State: customer.Active},
+	})
+	assert.Nil(t, err, "error should be nil")
+	assert.Nil(t, rs[0].Err, "valid update")
+	assert.Equal(t, customer.Passive, rs[0].Customer.State, "state should be set")
+	assert.True(t, errors.Is(rs[1].Err, registry.ErrValidation), "invalid state")
+	assert.True(t, errors.Is(rs[2].Err, registry.ErrNotFound), "missing ID")
+
+	c, err := svc.Get(ctx, 2)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, customer.Active, c.State, "failed item should not change")
+
+	entries, err := audit.Entries(ctx, 1)
+	assert.Nil(t, err, "error should be nil")
+	assert.Len(t, entries, 1, "successful item should be audited")
+}
+
+func TestInfoHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := registry.NewService(inmem.NewRepoWithSeed(seed(t)), registry.WithInfoHistory(inmem.NewInfoHistory()))
+
+	at := func(s string) time.Time {
+		tm, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			t.Fatalf("Failed to parse time from: %s, error: %v", s, err)
+		}
+		return tm
+	}
+
+	name := func(valid, recorded time.Time) string {
+		v, err := svc.GetAsOf(ctx, 2, valid, recorded)
+		if err != nil {
+			t.Fatalf("GetAsOf failed: %v", err)
+		}
+		return v.Info.(*customer.OrganizationInfo).Name
+	}
+
+	assert.Equal(t, "org-name", name(at("2019-06-30"), time.Time{}), "info before history is valid from the start")
+
+	renamed := testOrg(t)
+	renamed.Name = "new-name"
+	_, err := svc.UpdateInfo(ctx, 2, renamed)
+	assert.Nil(t, err, "error should be nil")
+
+	assert.Equal(t, "org-name", name(at("2019-06-30"), time.Time{}), "update should not change the past")
+	assert.Equal(t, "new-name", name(time.Time{}, time.Time{}), "update should be valid now")
+
+	before := time.Now()
+
+	corrected := testOrg(t)
+	corrected.Name = "old-name"
+	c, err := svc.CorrectInfo(ctx, 2, corrected, at("2019-01-01"), at("2020-01-01"))
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, "new-name", c.Info.(*customer.OrganizationInfo).Name, "past correction should not change current info")
+
+	assert.Equal(t, "old-name", name(at("2019-06-30"), time.Time{}), "correction should be valid in its period")
+	assert.Equal(t, "org-name", name(at("2019-06-30"), before), "earlier knowledge should be kept")
+	assert.Equal(t, "org-name", name(at("2018-06-30"), time.Time{}), "before the corrected period")
+	assert.Equal(t, "org-name", name(at("2020-06-30"), time.Time{}), "after the corrected period")
+	assert.Equal(t, "new-name", name(time.Time{}, time.Time{}), "current info")
+
+	c, err = svc.CorrectInfo(ctx, 2, corrected, at("2021-01-01"), time.Time{})
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, "old-name", c.Info.(*customer.OrganizationInfo).Name, "open ended correction should change current info")
+	assert.Equal(t, "old-name", name(at("2022-06-30"), time.Time{}), "open ended correction should be valid from its start")
+	assert.Equal(t, "new-name", name(time.Time{}, before), "earlier knowledge of current info should be kept")
+
+	_, err = svc.CorrectInfo(ctx, 2, corrected, time.Now().Add(time.Hour), time.Time{})
+	assert.True(t, errors.Is(err, registry.ErrValidation), "future corrections are rejected")
+
+	_, err = svc.CorrectInfo(ctx, 2, testOrg(t), at("2021-01-01"), time.Now().Add(time.Hour))
+	assert.True(t, errors.Is(err, registry.ErrValidation), "corrections ending in the future are rejected")
+	assert.Equal(t, "old-name", name(time.Time{}, time.Time{}), "rejected correction should not change current info")
+
+	_, err = svc.CorrectInfo(ctx, 2, corrected, at("2020-01-01"), at("2019-01-01"))
+	assert.True(t, errors.Is(err, registry.ErrValidation), "empty period is rejected")
+
+	person := testPerson(t)
+	person.SSN = "SSN-2"
+	c, err = svc.New(ctx, person)
+	assert.Nil(t, err, "error should be nil")
+	_, err = svc.GetAsOf(ctx, c.ID, at("2019-06-30"), time.Time{})
+	assert.True(t, errors.Is(err, registry.ErrNotFound), "new customer has no past")
+
+	_, err = registry.NewService(inmem.NewRepoWithSeed(seed(t))).GetAsOf(ctx, 2, time.Time{}, time.Time{})
+	assert.True(t, errors.Is(err, registry.ErrExpected), "history not enabled")
+
+	assert.Nil(t, svc.Erase(ctx, 1), "error should be nil")
+	_, err = svc.GetAsOf(ctx, 1, time.Time{}, time.Time{})
+	assert.True(t, errors.Is(err, registry.ErrExpected), "history of erased customers is gone")
+}
+
+func TestInfoHistoryConcurrentUpdates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	history := inmem.NewInfoHistory()
+	svc := registry.NewService(inmem.NewRepoWithSeed(seed(t)), registry.WithInfoHistory(history))
+
+	open := func() int {
+		vs, err := history.Versions(ctx, 2)
+		assert.Nil(t, err, "error should be nil")
+		n := 0
+		for _, v := range vs {
+			if v.SupersededAt.IsZero() && v.ValidTo.IsZero() {
+				n++
+			}
+		}
+		return n
+	}
+
+	update := func(wg *sync.WaitGroup, n int) {
+		defer wg.Done()
+		o := testOrg(t)
+		o.Name = fmt.Sprintf("name-%d", n)
+		_, err := svc.UpdateInfo(ctx, 2, o)
+		assert.Nil(t, err, "error should be nil")
+	}
+
+	var wg sync.WaitGroup
+	for n := 0; n < 20; n++ {
+		wg.Add(2)
+		go update(&wg, n)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, history.Purge(ctx, 2), "error should be nil")
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, open(), 1, "purges between updates should leave at most one open version")
+
+	for n := 0; n < 20; n++ {
+		wg.Add(1)
+		go update(&wg, n)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, open(), "concurrent updates should leave one open version")
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	audit := inmem.NewAuditLog()
+
+	// customer 3 duplicates customer 1 from before uniqueness checks
+	repo := inmem.NewRepoWithSeed(append(seed(t), customer.Customer{ID: 3, State: 1, Info: testPerson(t)}))
+	svc := registry.NewService(repo, registry.WithAuditLog(audit))
+
+	c, err := svc.Merge(ctx, 3, 1)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, uint32(1), c.ID, "Merge should return the survivor")
+
+	c, err = svc.Get(ctx, 3)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, uint32(1), c.ID, "Get should follow the redirect")
+
+	rs, err := svc.BatchGet(ctx, []uint32{3})
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, uint32(1), rs[0].Customer.ID, "BatchGet should follow the redirect")
+
+	entries, err := audit.Entries(ctx, 3)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, registry.OpMerge, entries[0].Op, "merge should be audited")
+	assert.Equal(t, uint32(1), entries[0].Related, "audit should name the survivor")
+
+	_, err = svc.Merge(ctx, 3, 1)
+	assert.Nil(t, err, "merging again should be a no-op")
+
+	entries, err = audit.Entries(ctx, 1)
+	assert.Nil(t, err, "error should be nil")
+	assert.Len(t, entries, 1, "no-op should not be audited")
+
+	_, err = svc.UpdateInfo(ctx, 1, testPerson(t))
+	assert.Nil(t, err, "survivor should not conflict with its redirect")
+
+	cs, err := svc.Search(ctx, "family", 10)
+	assert.Nil(t, err, "error should be nil")
+	assert.Len(t, cs, 1, "Search should skip redirects")
+
+	r, err := svc.SubjectAccessReport(ctx, "SSN")
+	assert.Nil(t, err, "error should be nil")
+	if assert.Len(t, r.Customers, 2, "report should list the redirect it still holds") {
+		assert.Zero(t, r.Customers[0].MergedInto, "survivor is not merged")
+		assert.Equal(t, uint32(1), r.Customers[1].MergedInto, "redirect should name the survivor")
+		assert.NotNil(t, r.Customers[1].MergedAt, "redirect should have a merge time")
+		assert.Regexp(t, `Merged into: +customer 1 at`, r.Text(), "text should show the merge")
+	}
+
+	_, err = svc.UpdateInfo(ctx, 3, testPerson(t))
+	assert.True(t, errors.Is(err, customer.ErrMerged), "redirect should not be updated")
+
+	assert.True(t, errors.Is(svc.SetState(ctx, 3, customer.Passive), customer.ErrMerged), "redirect state should not be set")
+
+	_, err = svc.Unmerge(ctx, 3)
+	assert.True(t, errors.Is(err, registry.ErrConflict), "duplicate SSN should not be restored")
+
+	testCases := []struct {
+		desc           string
+		source, target uint32
+		err            error
+	}{
+		{desc: "self", source: 1, target: 1, err: registry.ErrValidation},
+		{desc: "missing", source: 1, target: 99, err: registry.ErrNotFound},
+		{desc: "type mismatch", source: 2, target: 1, err: customer.ErrTypeNotEqual},
+		{desc: "into redirect", source: 1, target: 3, err: customer.ErrMerged},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := svc.Merge(ctx, tC.source, tC.target)
+			assert.True(t, errors.Is(err, tC.err), "Expected error should be found in the chain")
+		})
+	}
+
+	person := testPerson(t)
+	person.SSN = "SSN-2"
+	c, err = svc.New(ctx, person)
+	assert.Nil(t, err, "error should be nil")
+
+	_, err = svc.Merge(ctx, c.ID, 1)
+	assert.Nil(t, err, "error should be nil")
+
+	_, err = registry.NewService(repo, registry.WithMergeGracePeriod(0)).Unmerge(ctx, c.ID)
+	assert.True(t, errors.Is(err, registry.ErrGraceExpired), "grace period should be enforced")
+
+	restored, err := svc.Unmerge(ctx, c.ID)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, c.ID, restored.ID, "Unmerge should return the restored customer")
+
+	c, err = svc.Get(ctx, c.ID)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, restored.ID, c.ID, "restored customer should not redirect")
+
+	_, err = svc.Unmerge(ctx, c.ID)
+	assert.True(t, errors.Is(err, customer.ErrNotMerged), "customer is not merged")
+
+	person = testPerson(t)
+	person.SSN = "SSN-3"
+	c, err = svc.New(ctx, person)
+	assert.Nil(t, err, "error should be nil")
+	_, err = svc.Merge(ctx, 1, c.ID)
+	assert.Nil(t, err, "error should be nil")
+
+	survivor, err := svc.Merge(ctx, 3, 1)
+	assert.Nil(t, err, "merging again should be a no-op")
+	assert.Equal(t, c.ID, survivor.ID, "no-op should return the survivor of the target")
+}
+
+// slowRepo widens the window between reading and writing back a customer
+type slowRepo struct {
+	registry.Repo
+}
+
+func (r slowRepo) Get(ctx context.Context, id uint32) (*customer.Customer, error) {
+	time.Sleep(time.Millisecond)
+	return r.Repo.Get(ctx, id)
+}
+
+func TestConcurrentMerge(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	for n := 0; n < 20; n++ {
+		audit := inmem.NewAuditLog()
+		svc := registry.NewService(slowRepo{inmem.NewRepo()}, registry.WithAuditLog(audit))
+
+		var ids []uint32
+		for _, ssn := range []string{"SSN-1", "SSN-2", "SSN-3"} {
+			person := testPerson(t)
+			person.SSN = ssn
+			c, err := svc.New(ctx, person)
+			if err != nil {
+				t.Fatalf("Failed to create customer: %v", err)
+			}
+			ids = append(ids, c.ID)
+		}
+
+		errs := make(chan error, 3)
+		for _, target := range ids[1:] {
+			go func(target uint32) {
+				_, err := svc.Merge(ctx, ids[0], target)
+				errs <- err
+			}(target)
+		}
+		go func() {
+			errs <- svc.SetState(ctx, ids[0], customer.Passive)
+		}()
+
+		merged := 0
+		for i := 0; i < 3; i++ {
+			if err := <-errs; err == nil {
+				merged++
+			}
+		}
+
+		// the state change fails when it sees the merge and succeeds otherwise
+		assert.LessOrEqual(t, merged, 2, "source should be merged into one target only")
+
+		entries, err := audit.Entries(ctx, ids[0])
+		assert.Nil(t, err, "error should be nil")
+		merges := 0
+		for _, e := range entries {
+			if e.Op == registry.OpMerge {
+				merges++
+			}
+		}
+		assert.Equal(t, 1, merges, "source should be merged once")
+
+		c, err := svc.Get(ctx, ids[0])
+		assert.Nil(t, err, "error should be nil")
+		assert.NotEqual(t, ids[0], c.ID, "merge should not be lost to a concurrent state change")
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	duplicate := testPerson(t)
+	duplicate.GivenName = "given-nme"
+
+	repo := inmem.NewRepoWithSeed(append(seed(t), customer.Customer{ID: 3, State: 1, Info: duplicate}))
+
+	_, err := registry.NewService(repo).FindDuplicates(ctx, 0, 0)
+	assert.True(t, errors.Is(err, registry.ErrExpected), "duplicate detection not enabled")
+
+	svc := registry.NewService(repo, registry.WithDuplicateFinder(dedup.NewEngine()))
+
+	ds, err := svc.FindDuplicates(ctx, 0, 0)
+	assert.Nil(t, err, "error should be nil")
+	assert.Len(t, ds, 1, "one probable duplicate")
+	assert.Equal(t, uint32(1), ds[0].ID, "pair")
+	assert.Equal(t, uint32(3), ds[0].OtherID, "pair")
+
+	_, err = svc.FindDuplicates(ctx, 1.5, 0)
+	assert.True(t, errors.Is(err, registry.ErrValidation), "score above 1")
+
+	_, err = svc.Merge(ctx, 3, 1)
+	assert.Nil(t, err, "error should be nil")
+
+	ds, err = svc.FindDuplicates(ctx, 0, 0)
+	assert.Nil(t, err, "error should be nil")
+	assert.Empty(t, ds, "merged customers are not duplicates")
+}
+
 func seed(t *testing.T) []customer.Customer {
 
 	return []customer.Customer{
@@ -290,6 +1347,17 @@ func testPerson(t *testing.T) *customer.PersonInfo {
 		Citizenship: "US"}
 }
 
+func testSoleTrader(t *testing.T) *customer.SoleTraderInfo {
+	return &customer.SoleTraderInfo{
+		GivenName:   "given-name",
+		FamilyName:  "family-name",
+		SSN:         "SSN",
+		DateOfBirth: parseDate(t, "1970-01-01"),
+		Citizenship: "US",
+		TradeName:   "trade-name",
+		BusinessID:  "business-id"}
+}
+
 func testOrg(t *testing.T) *customer.OrganizationInfo {
 	return &customer.OrganizationInfo{
 		Name:                "org-name",
@@ -0,0 +1,64 @@
+package encrypted
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/registry"
+)
+
+// NewInfoHistory wraps a registry.InfoHistory so that versions are stored with their
+// sensitive values sealed under keys, as Repo stores customers
+func NewInfoHistory(next registry.InfoHistory, keys KeyProvider) *InfoHistory {
+	return &InfoHistory{
+		envelope: envelope{keys: keys},
+		next:     next,
+	}
+}
+
+type InfoHistory struct {
+	envelope
+	next registry.InfoHistory
+}
+
+func (h *InfoHistory) Versions(ctx context.Context, id uint32) ([]registry.InfoVersion, error) {
+	const op string = "encrypted.InfoHistory.Versions"
+
+	vs, err := h.next.Versions(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	for i := range vs {
+		if vs[i].Info, err = h.openInfo(ctx, vs[i].Info); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	return vs, nil
+}
+
+func (h *InfoHistory) Record(ctx context.Context, id uint32, prev customer.Info, v registry.InfoVersion) error {
+	const op string = "encrypted.InfoHistory.Record"
+
+	var err error
+
+	if prev != nil {
+		if prev, err = h.sealInfo(ctx, prev); err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+
+	if v.Info, err = h.sealInfo(ctx, v.Info); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return errors.Wrap(h.next.Record(ctx, id, prev, v), op)
+}
+
+func (h *InfoHistory) Purge(ctx context.Context, id uint32) error {
+	const op string = "encrypted.InfoHistory.Purge"
+
+	return errors.Wrap(h.next.Purge(ctx, id), op)
+}
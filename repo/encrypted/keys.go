@@ -0,0 +1,141 @@
+package encrypted
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+)
+
+var (
+	ErrKeyNotFound = errors.New("Key not found")
+	ErrInvalidKey  = errors.New("Invalid key")
+)
+
+// Key is a key encryption key used to wrap data keys
+type Key struct {
+	ID       string
+	Material []byte
+}
+
+type KeyProvider interface {
+	// CurrentKey returns the key used to wrap new data keys
+	CurrentKey(ctx context.Context) (Key, error)
+	// Key returns a current or retired key by ID for unwrapping
+	Key(ctx context.Context, id string) (Key, error)
+	// IndexKey returns the HMAC key for blind indexes, it is never rotated
+	IndexKey(ctx context.Context) ([]byte, error)
+}
+
+// keyFile is the format of a local key file, key material is base64 encoded 32 bytes:
+//
+//	{"current": "2021-03", "keys": {"2021-03": "..."}, "index_key": "..."}
+type keyFile struct {
+	Current  string            `json:"current"`
+	Keys     map[string]string `json:"keys"`
+	IndexKey string            `json:"index_key"`
+}
+
+// NewFileKeyProvider reads keys from a local JSON file, meant for tests and development
+func NewFileKeyProvider(path string) (*FileKeyProvider, error) {
+	kp := &FileKeyProvider{path: path}
+
+	if err := kp.Reload(); err != nil {
+		return nil, err
+	}
+
+	return kp, nil
+}
+
+type FileKeyProvider struct {
+	path string
+
+	mtx      sync.RWMutex
+	current  string
+	keys     map[string][]byte
+	indexKey []byte
+}
+
+// Reload re-reads the key file, used to rotate keys
+func (kp *FileKeyProvider) Reload() error {
+	const op string = "encrypted.FileKeyProvider.Reload"
+
+	b, err := ioutil.ReadFile(kp.path)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	var kf keyFile
+	if err := json.Unmarshal(b, &kf); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	keys := map[string][]byte{}
+	for id, enc := range kf.Keys {
+		if id == "" || strings.Contains(id, ":") {
+			return errors.Wrapf(ErrInvalidKey, "%s: key ID %q", op, id)
+		}
+		if keys[id], err = decodeKey(enc); err != nil {
+			return errors.Wrapf(err, "%s: key %s", op, id)
+		}
+	}
+
+	if _, ok := keys[kf.Current]; !ok {
+		return errors.Wrapf(ErrKeyNotFound, "%s: current key %s", op, kf.Current)
+	}
+
+	indexKey, err := decodeKey(kf.IndexKey)
+	if err != nil {
+		return errors.Wrapf(err, "%s: index key", op)
+	}
+
+	kp.mtx.Lock()
+	defer kp.mtx.Unlock()
+
+	kp.current, kp.keys, kp.indexKey = kf.Current, keys, indexKey
+
+	return nil
+}
+
+func (kp *FileKeyProvider) CurrentKey(ctx context.Context) (Key, error) {
+	kp.mtx.RLock()
+	defer kp.mtx.RUnlock()
+
+	return Key{ID: kp.current, Material: kp.keys[kp.current]}, nil
+}
+
+func (kp *FileKeyProvider) Key(ctx context.Context, id string) (Key, error) {
+	kp.mtx.RLock()
+	defer kp.mtx.RUnlock()
+
+	m, ok := kp.keys[id]
+	if !ok {
+		return Key{}, errors.Wrapf(ErrKeyNotFound, "encrypted.FileKeyProvider.Key: %s", id)
+	}
+
+	return Key{ID: id, Material: m}, nil
+}
+
+func (kp *FileKeyProvider) IndexKey(ctx context.Context) ([]byte, error) {
+	kp.mtx.RLock()
+	defer kp.mtx.RUnlock()
+
+	return kp.indexKey, nil
+}
+
+func decodeKey(enc string) ([]byte, error) {
+	k, err := base64.StdEncoding.DecodeString(enc)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(k) != 32 {
+		return nil, errors.Wrapf(ErrInvalidKey, "want 32 bytes, got %d", len(k))
+	}
+
+	return k, nil
+}
@@ -0,0 +1,753 @@
+// Package encrypted wraps a registry.Repo with envelope encryption of sensitive fields.
+//
+// Every sensitive value is encrypted with AES-GCM under a fresh data key, the data key is
+// wrapped with the current key encryption key from the KeyProvider and both are stored in
+// place of the plain value. Uniqueness and lookups use deterministic HMAC blind indexes.
+package encrypted
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/registry"
+	"github.com/nacobas/customer/tenant"
+)
+
+var (
+	ErrDecrypt = errors.New("Decryption failed")
+)
+
+const (
+	prefix = "enc:v1:"
+
+	KindSSN     = "ssn"
+	KindLegalID = "legal-id"
+	// KindTraderSSN indexes sole traders by SSN apart from private customers, a person may
+	// be both
+	KindTraderSSN = "trader-ssn"
+	// KindRedirect lists redirects of merged customers, which keep sealed info for Unmerge,
+	// for re-encryption. It is keyed by customer ID and never looked up.
+	KindRedirect = "redirect"
+)
+
+// BlindIndex maps blind indexes of identifiers to customer IDs of the request tenant
+type BlindIndex interface {
+	// Put maps hash to id, replacing any previous hash of id for the same kind
+	Put(ctx context.Context, kind, hash string, id uint32) error
+	Lookup(ctx context.Context, kind, hash string) (uint32, bool, error)
+	// Delete removes the hash of id for kind, if any
+	Delete(ctx context.Context, kind string, id uint32) error
+	IDs(ctx context.Context) ([]uint32, error)
+	Tenants(ctx context.Context) ([]string, error)
+}
+
+func NewRepo(next registry.Repo, keys KeyProvider, index BlindIndex) *Repo {
+	return &Repo{
+		envelope: envelope{keys: keys},
+		next:     next,
+		index:    index,
+	}
+}
+
+type Repo struct {
+	envelope
+	next  registry.Repo
+	index BlindIndex
+	// mtx makes the uniqueness check and the write atomic
+	mtx sync.Mutex
+}
+
+func (r *Repo) Get(ctx context.Context, id uint32) (*customer.Customer, error) {
+	const op string = "encrypted.Repo.Get"
+
+	c, err := r.next.Get(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	d, err := r.decrypt(ctx, c)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return d, nil
+}
+
+func (r *Repo) Insert(ctx context.Context, c *customer.Customer) error {
+	const op string = "encrypted.Repo.Insert"
+
+	return errors.Wrap(r.write(ctx, c, r.next.Insert), op)
+}
+
+func (r *Repo) Update(ctx context.Context, c *customer.Customer) error {
+	const op string = "encrypted.Repo.Update"
+
+	return errors.Wrap(r.write(ctx, c, r.next.Update), op)
+}
+
+func (r *Repo) BatchGet(ctx context.Context, ids []uint32) ([]*customer.Customer, error) {
+	const op string = "encrypted.Repo.BatchGet"
+
+	cs, err := r.next.BatchGet(ctx, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	found := make([]*customer.Customer, len(cs))
+	for i, c := range cs {
+		if c == nil {
+			continue
+		}
+		if found[i], err = r.decrypt(ctx, c); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	return found, nil
+}
+
+// BatchUpdate checks uniqueness and encrypts every customer, then writes the batch through
+// the wrapped repo at once
+func (r *Repo) BatchUpdate(ctx context.Context, cs []*customer.Customer) ([]error, error) {
+	const op string = "encrypted.Repo.BatchUpdate"
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	var (
+		errs    = make([]error, len(cs))
+		hashes  = make([][]string, len(cs))
+		pending []int
+		sealed  []*customer.Customer
+	)
+
+	for i, c := range cs {
+		hs, err := r.unique(ctx, c)
+		if errors.Is(err, registry.ErrConflict) {
+			errs[i] = errors.Wrap(err, op)
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+
+		e, err := r.encrypt(ctx, c)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+
+		hashes[i] = hs
+		pending = append(pending, i)
+		sealed = append(sealed, e)
+	}
+
+	written, err := r.next.BatchUpdate(ctx, sealed)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	for j, i := range pending {
+		if errs[i] = written[j]; errs[i] != nil {
+			continue
+		}
+
+		if err := r.put(ctx, cs[i], hashes[i]); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	return errs, nil
+}
+
+func (r *Repo) FindBySSN(ctx context.Context, ssn string) ([]*customer.Customer, error) {
+	const op string = "encrypted.Repo.FindBySSN"
+
+	var found []*customer.Customer
+
+	for _, kind := range []string{KindSSN, KindTraderSSN} {
+		hash, err := r.blind(ctx, kind, ssn)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+
+		id, ok, err := r.index.Lookup(ctx, kind, hash)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		if !ok {
+			continue
+		}
+
+		c, err := r.Get(ctx, id)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		found = append(found, c)
+	}
+
+	return found, nil
+}
+
+// FindMergedBySSN opens every redirect, sealed values cannot be compared and redirects are
+// not in the blind index
+func (r *Repo) FindMergedBySSN(ctx context.Context, ssn string) ([]*customer.Customer, error) {
+	const op string = "encrypted.Repo.FindMergedBySSN"
+
+	ids, err := r.index.IDs(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	cs, err := r.next.BatchGet(ctx, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	var found []*customer.Customer
+
+	ssn = customer.CanonicalID(ssn)
+
+	for _, c := range cs {
+		if c == nil || c.MergedInto == 0 {
+			continue
+		}
+
+		d, err := r.decrypt(ctx, c)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+
+		if pi, ok := customer.PersonOf(d.Info); ok && customer.CanonicalID(pi.SSN) == ssn {
+			found = append(found, d)
+		}
+	}
+
+	return found, nil
+}
+
+func (r *Repo) List(ctx context.Context, after uint32, limit int) ([]*customer.Customer, error) {
+	const op string = "encrypted.Repo.List"
+
+	cs, err := r.next.List(ctx, after, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return r.decryptAll(ctx, cs, op)
+}
+
+// Search matches names through the wrapped repo and identifiers through the blind index
+func (r *Repo) Search(ctx context.Context, query string, limit int) ([]*customer.Customer, error) {
+	const op string = "encrypted.Repo.Search"
+
+	cs, err := r.next.Search(ctx, query, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	found, err := r.decryptAll(ctx, cs, op)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, kind := range []string{KindSSN, KindLegalID, KindTraderSSN} {
+		hash, err := r.blind(ctx, kind, query)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+
+		id, ok, err := r.index.Lookup(ctx, kind, hash)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+
+		if !ok || containsID(found, id) {
+			continue
+		}
+
+		c, err := r.Get(ctx, id)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+
+		if !c.Erased && c.MergedInto == 0 {
+			found = append([]*customer.Customer{c}, found...)
+		}
+	}
+
+	if len(found) > limit {
+		found = found[:limit]
+	}
+
+	return found, nil
+}
+
+func (r *Repo) decryptAll(ctx context.Context, cs []*customer.Customer, op string) ([]*customer.Customer, error) {
+	found := make([]*customer.Customer, 0, len(cs))
+
+	for _, c := range cs {
+		d, err := r.decrypt(ctx, c)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		found = append(found, d)
+	}
+
+	return found, nil
+}
+
+func containsID(cs []*customer.Customer, id uint32) bool {
+	for _, c := range cs {
+		if c.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Reencrypt rewraps every value of every tenant not encrypted under the current key and
+// returns the number of customers rewritten.
+func (r *Repo) Reencrypt(ctx context.Context) (int, error) {
+	const op string = "encrypted.Repo.Reencrypt"
+
+	current, err := r.keys.CurrentKey(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	tenants, err := r.index.Tenants(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	n := 0
+	for _, t := range tenants {
+		m, err := r.reencrypt(tenant.NewContext(ctx, t), current.ID)
+		n += m
+		if err != nil {
+			return n, errors.Wrapf(err, "%s: tenant %q", op, t)
+		}
+	}
+
+	return n, nil
+}
+
+func (r *Repo) reencrypt(ctx context.Context, current string) (int, error) {
+	ids, err := r.index.IDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return n, err
+		}
+
+		done, err := r.reencryptOne(ctx, id, current)
+		if err != nil {
+			return n, err
+		}
+		if done {
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// reencryptOne holds mtx from the read to the write back, so that a concurrent write is not
+// overwritten with the values read before it
+func (r *Repo) reencryptOne(ctx context.Context, id uint32, current string) (bool, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	raw, err := r.next.Get(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	if !stale(raw, current) {
+		return false, nil
+	}
+
+	c, err := r.decrypt(ctx, raw)
+	if err != nil {
+		return false, err
+	}
+
+	return true, r.store(ctx, c, r.next.Update)
+}
+
+// reloader is implemented by key providers that re-read their keys, such as FileKeyProvider
+type reloader interface {
+	Reload() error
+}
+
+// RunRotation reloads the keys, when the KeyProvider supports it, and calls Reencrypt every
+// interval until ctx is done. Errors are passed to onError. Intervals of zero or less disable
+// rotation.
+func (r *Repo) RunRotation(ctx context.Context, every time.Duration, onError func(error)) {
+	if every <= 0 {
+		return
+	}
+
+	t := time.NewTicker(every)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := r.rotate(ctx); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+func (r *Repo) rotate(ctx context.Context) error {
+	if rl, ok := r.keys.(reloader); ok {
+		if err := rl.Reload(); err != nil {
+			return err
+		}
+	}
+
+	_, err := r.Reencrypt(ctx)
+	return err
+}
+
+// write checks uniqueness and indexes c, except for redirects of merged customers which may
+// duplicate their survivor
+func (r *Repo) write(ctx context.Context, c *customer.Customer, next func(context.Context, *customer.Customer) error) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return r.store(ctx, c, next)
+}
+
+// store checks uniqueness, encrypts c, writes it with next and indexes it. Must be called
+// with mtx held.
+func (r *Repo) store(ctx context.Context, c *customer.Customer, next func(context.Context, *customer.Customer) error) error {
+	hashes, err := r.unique(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	e, err := r.encrypt(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	if err := next(ctx, e); err != nil {
+		return err
+	}
+
+	return r.put(ctx, c, hashes)
+}
+
+// unique returns the blind indexes of the identifiers of c, or ErrConflict when another
+// customer has one of them. Must be called with mtx held.
+func (r *Repo) unique(ctx context.Context, c *customer.Customer) ([]string, error) {
+	ids := identifiers(c)
+	hashes := make([]string, len(ids))
+
+	for i, id := range ids {
+		hash, err := r.blind(ctx, id.kind, id.value)
+		if err != nil {
+			return nil, err
+		}
+
+		other, ok, err := r.index.Lookup(ctx, id.kind, hash)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok && other != c.ID && c.MergedInto == 0 {
+			return nil, errors.Mark(errors.Newf("%s already in use", id.kind), registry.ErrConflict)
+		}
+
+		hashes[i] = hash
+	}
+
+	return hashes, nil
+}
+
+// put indexes c by the hashes returned by unique. Kinds c no longer has after a type
+// conversion are removed, as are all entries of redirects of merged customers so that
+// lookups find the survivor only. Unmerge indexes the restored customer again.
+func (r *Repo) put(ctx context.Context, c *customer.Customer, hashes []string) error {
+	kept := map[string]bool{}
+
+	if c.MergedInto != 0 {
+		if err := r.index.Put(ctx, KindRedirect, strconv.FormatUint(uint64(c.ID), 10), c.ID); err != nil {
+			return err
+		}
+		kept[KindRedirect] = true
+	} else {
+		for i, id := range identifiers(c) {
+			if err := r.index.Put(ctx, id.kind, hashes[i], c.ID); err != nil {
+				return err
+			}
+			kept[id.kind] = true
+		}
+	}
+
+	for _, kind := range []string{KindSSN, KindLegalID, KindTraderSSN, KindRedirect} {
+		if kept[kind] {
+			continue
+		}
+		if err := r.index.Delete(ctx, kind, c.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Repo) encrypt(ctx context.Context, c *customer.Customer) (*customer.Customer, error) {
+	e := *c
+
+	i, err := r.sealInfo(ctx, c.Info)
+	if err != nil {
+		return nil, err
+	}
+	e.Info = i
+
+	return &e, nil
+}
+
+func (r *Repo) decrypt(ctx context.Context, c *customer.Customer) (*customer.Customer, error) {
+	d := *c
+
+	i, err := r.openInfo(ctx, c.Info)
+	if err != nil {
+		return nil, err
+	}
+	d.Info = i
+
+	return &d, nil
+}
+
+// envelope seals and opens sensitive values under the keys of a KeyProvider
+type envelope struct {
+	keys KeyProvider
+}
+
+// sealInfo returns a copy of i with its sensitive values sealed
+func (e envelope) sealInfo(ctx context.Context, i customer.Info) (customer.Info, error) {
+	return e.transform(ctx, i, e.seal)
+}
+
+// openInfo returns a copy of i with its sensitive values opened
+func (e envelope) openInfo(ctx context.Context, i customer.Info) (customer.Info, error) {
+	return e.transform(ctx, i, e.open)
+}
+
+func (e envelope) transform(ctx context.Context, i customer.Info, f func(context.Context, string) (string, error)) (customer.Info, error) {
+	switch i := i.(type) {
+	case *customer.PersonInfo:
+		pi := *i
+		ssn, err := f(ctx, i.SSN)
+		if err != nil {
+			return nil, err
+		}
+		pi.SSN = ssn
+		return &pi, nil
+	case *customer.SoleTraderInfo:
+		si := *i
+		ssn, err := f(ctx, i.SSN)
+		if err != nil {
+			return nil, err
+		}
+		id, err := f(ctx, i.BusinessID)
+		if err != nil {
+			return nil, err
+		}
+		si.SSN, si.BusinessID = ssn, id
+		return &si, nil
+	case *customer.OrganizationInfo:
+		oi := *i
+		id, err := f(ctx, i.LeagalID)
+		if err != nil {
+			return nil, err
+		}
+		oi.LeagalID = id
+		return &oi, nil
+	}
+
+	return i, nil
+}
+
+// seal encrypts plain under a new data key, the result is
+// enc:v1:<key id>:<wrapped data key>:<ciphertext>
+func (e envelope) seal(ctx context.Context, plain string) (string, error) {
+	kek, err := e.keys.CurrentKey(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	dek := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dek); err != nil {
+		return "", err
+	}
+
+	wrapped, err := gcmSeal(kek.Material, dek, []byte(kek.ID))
+	if err != nil {
+		return "", err
+	}
+
+	ct, err := gcmSeal(dek, []byte(plain), nil)
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawStdEncoding
+	return prefix + kek.ID + ":" + enc.EncodeToString(wrapped) + ":" + enc.EncodeToString(ct), nil
+}
+
+// open decrypts a sealed value, values without the prefix are returned as is
+func (e envelope) open(ctx context.Context, sealed string) (string, error) {
+	if !strings.HasPrefix(sealed, prefix) {
+		return sealed, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(sealed, prefix), ":")
+	if len(parts) != 3 {
+		return "", errors.Wrap(ErrDecrypt, "malformed value")
+	}
+
+	kek, err := e.keys.Key(ctx, parts[0])
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawStdEncoding
+
+	wrapped, err := enc.DecodeString(parts[1])
+	if err != nil {
+		return "", errors.Mark(err, ErrDecrypt)
+	}
+
+	ct, err := enc.DecodeString(parts[2])
+	if err != nil {
+		return "", errors.Mark(err, ErrDecrypt)
+	}
+
+	dek, err := gcmOpen(kek.Material, wrapped, []byte(kek.ID))
+	if err != nil {
+		return "", errors.Mark(err, ErrDecrypt)
+	}
+
+	plain, err := gcmOpen(dek, ct, nil)
+	if err != nil {
+		return "", errors.Mark(err, ErrDecrypt)
+	}
+
+	return string(plain), nil
+}
+
+// blind returns the keyed hash of the canonical identifier, equal identifiers written
+// differently share it
+func (r *Repo) blind(ctx context.Context, kind, value string) (string, error) {
+	k, err := r.keys.IndexKey(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	m := hmac.New(sha256.New, k)
+	m.Write([]byte(kind + ":" + customer.CanonicalID(value)))
+
+	return hex.EncodeToString(m.Sum(nil)), nil
+}
+
+// identifier is a unique value of a customer and the kind of its blind index
+type identifier struct {
+	kind, value string
+}
+
+// identifiers returns the unique identifiers of c, a sole trader shares the business
+// register with organizations
+func identifiers(c *customer.Customer) []identifier {
+	switch i := c.Info.(type) {
+	case *customer.PersonInfo:
+		return []identifier{{KindSSN, i.SSN}}
+	case *customer.OrganizationInfo:
+		return []identifier{{KindLegalID, i.LeagalID}}
+	case *customer.SoleTraderInfo:
+		return []identifier{{KindLegalID, i.BusinessID}, {KindTraderSSN, i.SSN}}
+	}
+	return nil
+}
+
+// sensitive returns the encrypted values of c
+func sensitive(c *customer.Customer) []string {
+	switch i := c.Info.(type) {
+	case *customer.PersonInfo:
+		return []string{i.SSN}
+	case *customer.OrganizationInfo:
+		return []string{i.LeagalID}
+	case *customer.SoleTraderInfo:
+		return []string{i.SSN, i.BusinessID}
+	}
+	return nil
+}
+
+// stale reports whether a sensitive value of c is plain or under another key
+func stale(c *customer.Customer, current string) bool {
+	for _, v := range sensitive(c) {
+		if !strings.HasPrefix(v, prefix+current+":") {
+			return true
+		}
+	}
+	return false
+}
+
+func gcmSeal(key, plain, aad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plain, aad), nil
+}
+
+func gcmOpen(key, sealed, aad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], aad)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	b, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(b)
+}
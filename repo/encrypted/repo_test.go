@@ -0,0 +1,447 @@
+package encrypted_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/registry"
+	"github.com/nacobas/customer/repo/encrypted"
+	"github.com/nacobas/customer/repo/inmem"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptedRepo(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+	keys := map[string]string{"k1": newKey(t)}
+	indexKey := newKey(t)
+	writeKeys(t, path, "k1", keys, indexKey)
+
+	kp, err := encrypted.NewFileKeyProvider(path)
+	assert.Nil(t, err, "key file should load")
+
+	inner := inmem.NewRepo()
+	repo := encrypted.NewRepo(inner, kp, inmem.NewBlindIndex())
+
+	person := &customer.Customer{ID: 1, State: 1, Info: testPerson(t, "SSN-1")}
+	org := &customer.Customer{ID: 2, State: 1, Info: testOrg(t, "legal-id")}
+
+	assert.Nil(t, repo.Insert(ctx, person), "insert person")
+	assert.Nil(t, repo.Insert(ctx, org), "insert org")
+
+	raw, err := inner.Get(ctx, 1)
+	assert.Nil(t, err, "error should be nil")
+	assert.True(t, strings.HasPrefix(raw.Info.(*customer.PersonInfo).SSN, "enc:v1:k1:"), "SSN should be encrypted at rest")
+
+	raw, err = inner.Get(ctx, 2)
+	assert.Nil(t, err, "error should be nil")
+	assert.NotEqual(t, "legal-id", raw.Info.(*customer.OrganizationInfo).LeagalID, "legal ID should be encrypted at rest")
+
+	got, err := repo.Get(ctx, 1)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, person, got, "Get should decrypt")
+	assert.Equal(t, "SSN-1", person.Info.(*customer.PersonInfo).SSN, "input should not be modified")
+
+	found, err := repo.FindBySSN(ctx, "SSN-1")
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, []*customer.Customer{person}, found, "lookup by SSN should use the blind index")
+
+	found, err = repo.FindBySSN(ctx, "SSN-2")
+	assert.Nil(t, err, "error should be nil")
+	assert.Empty(t, found, "unknown SSN should not be found")
+
+	err = repo.Insert(ctx, &customer.Customer{ID: 3, State: 1, Info: testPerson(t, "SSN-1")})
+	assert.True(t, errors.Is(err, registry.ErrConflict), "SSN should stay unique")
+
+	updated := &customer.Customer{ID: 1, State: 1, Info: testPerson(t, "SSN-2")}
+	assert.Nil(t, repo.Update(ctx, updated), "update SSN")
+	found, err = repo.FindBySSN(ctx, "SSN-1")
+	assert.Nil(t, err, "error should be nil")
+	assert.Empty(t, found, "old SSN should be released")
+
+	batch, err := repo.BatchGet(ctx, []uint32{2, 1, 9})
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, []*customer.Customer{org, updated, nil}, batch, "BatchGet should decrypt")
+
+	errs, err := repo.BatchUpdate(ctx, []*customer.Customer{
+		{ID: 1, State: 2, Info: testPerson(t, "SSN-2")},
+		{ID: 2, State: 1, Info: testOrg(t, "legal-id")},
+		{ID: 3, State: 1, Info: testOrg(t, "legal-id")},
+	})
+	assert.Nil(t, err, "error should be nil")
+	assert.Nil(t, errs[0], "update person")
+	assert.Nil(t, errs[1], "update org")
+	assert.True(t, errors.Is(errs[2], registry.ErrConflict), "legal ID should stay unique in a batch")
+
+	raw, err = inner.Get(ctx, 1)
+	assert.Nil(t, err, "error should be nil")
+	assert.True(t, strings.HasPrefix(raw.Info.(*customer.PersonInfo).SSN, "enc:v1:k1:"), "batch should be encrypted at rest")
+	updated.State = 2
+
+	// rotate
+	keys["k2"] = newKey(t)
+	writeKeys(t, path, "k2", keys, indexKey)
+	assert.Nil(t, kp.Reload(), "key file should reload")
+
+	n, err := repo.Reencrypt(ctx)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, 2, n, "every customer should be re-encrypted")
+
+	raw, err = inner.Get(ctx, 1)
+	assert.Nil(t, err, "error should be nil")
+	assert.True(t, strings.HasPrefix(raw.Info.(*customer.PersonInfo).SSN, "enc:v1:k2:"), "SSN should be under the new key")
+
+	n, err = repo.Reencrypt(ctx)
+	assert.Nil(t, err, "error should be nil")
+	assert.Zero(t, n, "nothing left to re-encrypt")
+
+	got, err = repo.Get(ctx, 1)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, updated, got, "Get should decrypt after rotation")
+}
+
+func TestRunRotation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+	keys := map[string]string{"k1": newKey(t)}
+	indexKey := newKey(t)
+	writeKeys(t, path, "k1", keys, indexKey)
+
+	kp, err := encrypted.NewFileKeyProvider(path)
+	assert.Nil(t, err, "key file should load")
+
+	inner := inmem.NewRepo()
+	repo := encrypted.NewRepo(inner, kp, inmem.NewBlindIndex())
+	assert.Nil(t, repo.Insert(ctx, &customer.Customer{ID: 1, State: 1, Info: testPerson(t, "SSN-1")}), "insert")
+
+	keys["k2"] = newKey(t)
+	writeKeys(t, path, "k2", keys, indexKey)
+
+	errs := make(chan error, 1)
+	go repo.RunRotation(ctx, time.Millisecond, func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	})
+
+	assert.Eventually(t, func() bool {
+		raw, err := inner.Get(ctx, 1)
+		return err == nil && strings.HasPrefix(raw.Info.(*customer.PersonInfo).SSN, "enc:v1:k2:")
+	}, 5*time.Second, time.Millisecond, "rotation should pick up the new key file")
+
+	select {
+	case err := <-errs:
+		t.Errorf("Rotation failed: %v", err)
+	default:
+	}
+
+	done := make(chan struct{})
+	go func() {
+		repo.RunRotation(ctx, 0, nil)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("zero interval should disable rotation")
+	}
+}
+
+func TestReencryptConcurrentWrites(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+	keys := map[string]string{"k1": newKey(t)}
+	indexKey := newKey(t)
+	writeKeys(t, path, "k1", keys, indexKey)
+
+	kp, err := encrypted.NewFileKeyProvider(path)
+	assert.Nil(t, err, "key file should load")
+
+	inner := inmem.NewRepo()
+	repo := encrypted.NewRepo(inner, kp, inmem.NewBlindIndex())
+
+	const n = 200
+	for id := uint32(1); id <= n; id++ {
+		c := &customer.Customer{ID: id, State: 1, Info: testPerson(t, fmt.Sprintf("SSN-%d", id))}
+		if err := repo.Insert(ctx, c); err != nil {
+			t.Fatalf("Failed to insert customer %d: %v", id, err)
+		}
+	}
+
+	keys["k2"] = newKey(t)
+	writeKeys(t, path, "k2", keys, indexKey)
+	assert.Nil(t, kp.Reload(), "key file should reload")
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := repo.Reencrypt(ctx)
+		errs <- err
+	}()
+
+	// even customers are updated and odd ones erased while their keys rotate
+	for id := uint32(1); id <= n; id++ {
+		c, err := repo.Get(ctx, id)
+		if err != nil {
+			t.Fatalf("Failed to get customer %d: %v", id, err)
+		}
+
+		if id%2 == 0 {
+			c.Info.(*customer.PersonInfo).GivenName = "updated"
+		} else if err := c.Erase(); err != nil {
+			t.Fatalf("Failed to erase customer %d: %v", id, err)
+		}
+
+		if err := repo.Update(ctx, c); err != nil {
+			t.Fatalf("Failed to update customer %d: %v", id, err)
+		}
+	}
+
+	assert.Nil(t, <-errs, "error should be nil")
+
+	for id := uint32(1); id <= n; id++ {
+		c, err := repo.Get(ctx, id)
+		if err != nil {
+			t.Fatalf("Failed to get customer %d: %v", id, err)
+		}
+
+		if id%2 == 0 {
+			assert.Equal(t, "updated", c.Info.(*customer.PersonInfo).GivenName, "update of %d should not be lost", id)
+		} else {
+			assert.True(t, c.Erased, "erasure of %d should not be undone", id)
+		}
+	}
+}
+
+func TestEncryptedSoleTrader(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+	writeKeys(t, path, "k1", map[string]string{"k1": newKey(t)}, newKey(t))
+
+	kp, err := encrypted.NewFileKeyProvider(path)
+	assert.Nil(t, err, "key file should load")
+
+	inner := inmem.NewRepo()
+	repo := encrypted.NewRepo(inner, kp, inmem.NewBlindIndex())
+
+	person := &customer.Customer{ID: 1, State: 1, Info: testPerson(t, "SSN-1")}
+	trader := &customer.Customer{ID: 2, State: 1, Info: testSoleTrader(t, "SSN-1", "business-id")}
+
+	assert.Nil(t, repo.Insert(ctx, person), "insert person")
+	assert.Nil(t, repo.Insert(ctx, trader), "a person may also be a sole trader")
+
+	raw, err := inner.Get(ctx, 2)
+	assert.Nil(t, err, "error should be nil")
+	assert.True(t, strings.HasPrefix(raw.Info.(*customer.SoleTraderInfo).SSN, "enc:v1:k1:"), "SSN should be encrypted at rest")
+	assert.True(t, strings.HasPrefix(raw.Info.(*customer.SoleTraderInfo).BusinessID, "enc:v1:k1:"), "business ID should be encrypted at rest")
+
+	got, err := repo.Get(ctx, 2)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, trader, got, "Get should decrypt the business ID")
+
+	found, err := repo.Search(ctx, "business-id", 10)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, []*customer.Customer{trader}, found, "search by business ID should use the blind index")
+
+	// a business ID stored before it was sealed
+	raw.Info.(*customer.SoleTraderInfo).BusinessID = "business-id"
+	assert.Nil(t, inner.Update(ctx, raw), "store plain business ID")
+	n, err := repo.Reencrypt(ctx)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, 1, n, "plain business ID should be re-encrypted")
+	raw, err = inner.Get(ctx, 2)
+	assert.Nil(t, err, "error should be nil")
+	assert.True(t, strings.HasPrefix(raw.Info.(*customer.SoleTraderInfo).BusinessID, "enc:v1:k1:"), "business ID should be sealed by Reencrypt")
+
+	found, err = repo.FindBySSN(ctx, "SSN-1")
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, []*customer.Customer{person, trader}, found, "person and sole trader found by SSN")
+
+	err = repo.Insert(ctx, &customer.Customer{ID: 3, State: 1, Info: testSoleTrader(t, "SSN-1", "other-id")})
+	assert.True(t, errors.Is(err, registry.ErrConflict), "a person is one sole trader")
+
+	err = repo.Insert(ctx, &customer.Customer{ID: 3, State: 1, Info: testOrg(t, "business-id")})
+	assert.True(t, errors.Is(err, registry.ErrConflict), "business IDs share the register with legal IDs")
+
+	assert.Nil(t, repo.Update(ctx, &customer.Customer{ID: 2, State: 1, Info: testPerson(t, "SSN-2")}), "sole trader stops trading")
+	assert.Nil(t, repo.Insert(ctx, &customer.Customer{ID: 3, State: 1, Info: testSoleTrader(t, "SSN-1", "business-id")}), "identifiers of the sole trader should be released")
+}
+
+func TestEncryptedMerge(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+	keys := map[string]string{"k1": newKey(t)}
+	indexKey := newKey(t)
+	writeKeys(t, path, "k1", keys, indexKey)
+
+	kp, err := encrypted.NewFileKeyProvider(path)
+	assert.Nil(t, err, "key file should load")
+
+	inner := inmem.NewRepo()
+	repo := encrypted.NewRepo(inner, kp, inmem.NewBlindIndex())
+
+	survivor := &customer.Customer{ID: 1, State: 1, Info: testPerson(t, "SSN-1")}
+	source := &customer.Customer{ID: 2, State: 1, Info: testPerson(t, "SSN-2")}
+	assert.Nil(t, repo.Insert(ctx, survivor), "insert survivor")
+	assert.Nil(t, repo.Insert(ctx, source), "insert source")
+
+	merged := *source
+	merged.MergedInto = survivor.ID
+	assert.Nil(t, repo.Update(ctx, &merged), "merge")
+
+	found, err := repo.FindBySSN(ctx, "SSN-2")
+	assert.Nil(t, err, "error should be nil")
+	assert.Empty(t, found, "redirect should not be found by SSN")
+
+	found, err = repo.FindMergedBySSN(ctx, "SSN-2")
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, []*customer.Customer{&merged}, found, "redirect should be found among merged customers")
+
+	found, err = repo.FindBySSN(ctx, "SSN-1")
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, []*customer.Customer{survivor}, found, "survivor should still be found")
+
+	keys["k2"] = newKey(t)
+	writeKeys(t, path, "k2", keys, indexKey)
+	assert.Nil(t, kp.Reload(), "key file should reload")
+	n, err := repo.Reencrypt(ctx)
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, 2, n, "redirect should be re-encrypted with the survivor")
+
+	assert.Nil(t, repo.Update(ctx, source), "unmerge")
+	found, err = repo.FindBySSN(ctx, "SSN-2")
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, []*customer.Customer{source}, found, "unmerged customer should be indexed again")
+
+	found, err = repo.FindMergedBySSN(ctx, "SSN-2")
+	assert.Nil(t, err, "error should be nil")
+	assert.Empty(t, found, "unmerged customer should not be found among merged customers")
+
+	err = repo.Insert(ctx, &customer.Customer{ID: 3, State: 1, Info: testPerson(t, "SSN-2")})
+	assert.True(t, errors.Is(err, registry.ErrConflict), "SSN of the unmerged customer should be unique again")
+}
+
+func TestEncryptedInfoHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+	writeKeys(t, path, "k1", map[string]string{"k1": newKey(t)}, newKey(t))
+
+	kp, err := encrypted.NewFileKeyProvider(path)
+	assert.Nil(t, err, "key file should load")
+
+	inner := inmem.NewInfoHistory()
+	history := encrypted.NewInfoHistory(inner, kp)
+
+	now := time.Now()
+	prev := testPerson(t, "SSN-1")
+	v := registry.InfoVersion{Info: testPerson(t, "SSN-2"), ValidFrom: now, RecordedAt: now}
+	assert.Nil(t, history.Record(ctx, 1, prev, v), "record")
+
+	raw, err := inner.Versions(ctx, 1)
+	assert.Nil(t, err, "error should be nil")
+	if assert.Len(t, raw, 3, "superseded previous info, its part before the new version and the new version") {
+		for _, rv := range raw {
+			assert.True(t, strings.HasPrefix(rv.Info.(*customer.PersonInfo).SSN, "enc:v1:k1:"), "SSN should be encrypted at rest")
+		}
+	}
+
+	vs, err := history.Versions(ctx, 1)
+	assert.Nil(t, err, "error should be nil")
+	if assert.Len(t, vs, 3, "versions") {
+		assert.Equal(t, prev, vs[0].Info, "previous info should decrypt")
+		assert.Equal(t, now, vs[0].SupersededAt, "previous info should be superseded")
+		assert.Equal(t, v.Info, vs[2].Info, "new version should decrypt")
+	}
+	assert.Equal(t, "SSN-2", v.Info.(*customer.PersonInfo).SSN, "input should not be modified")
+
+	assert.Nil(t, history.Purge(ctx, 1), "purge")
+	vs, err = history.Versions(ctx, 1)
+	assert.Nil(t, err, "error should be nil")
+	assert.Empty(t, vs, "purge should forget the versions")
+}
+
+func newKey(t *testing.T) string {
+	k := make([]byte, 32)
+	if _, err := rand.Read(k); err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(k)
+}
+
+func writeKeys(t *testing.T, path, current string, keys map[string]string, indexKey string) {
+	b, err := json.Marshal(map[string]interface{}{"current": current, "keys": keys, "index_key": indexKey})
+	if err != nil {
+		t.Fatalf("Failed to marshal keys: %v", err)
+	}
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		t.Fatalf("Failed to write key file: %v", err)
+	}
+}
+
+func testSoleTrader(t *testing.T, ssn, businessID string) *customer.SoleTraderInfo {
+	return &customer.SoleTraderInfo{
+		GivenName:   "given-name",
+		FamilyName:  "family-name",
+		SSN:         ssn,
+		DateOfBirth: parseDate(t, "1970-01-01"),
+		Citizenship: "US",
+		TradeName:   "trade-name",
+		BusinessID:  businessID,
+	}
+}
+
+func testPerson(t *testing.T, ssn string) *customer.PersonInfo {
+	return &customer.PersonInfo{
+		GivenName:   "given-name",
+		FamilyName:  "family-name",
+		SSN:         ssn,
+		DateOfBirth: parseDate(t, "1970-01-01"),
+		Citizenship: "US"}
+}
+
+func testOrg(t *testing.T, legalID string) *customer.OrganizationInfo {
+	return &customer.OrganizationInfo{
+		Name:                "org-name",
+		Form:                "Ltd",
+		LeagalID:            legalID,
+		RegistrationDate:    parseDate(t, "1970-01-01"),
+		RegistrationCountry: "US"}
+}
+
+func parseDate(t *testing.T, datestr string) date.Date {
+	d, err := date.ParseDate(datestr)
+	if err != nil {
+		t.Fatalf("Failed to parse date from: %s, error: %v", datestr, err)
+	}
+	return d
+}
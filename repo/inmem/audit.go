@@ -0,0 +1,48 @@
+package inmem
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nacobas/customer/registry"
+	"github.com/nacobas/customer/tenant"
+)
+
+func NewAuditLog() registry.AuditLog {
+	return &auditLog{
+		mtx:     sync.RWMutex{},
+		entries: map[key][]registry.AuditEntry{},
+	}
+}
+
+type auditLog struct {
+	mtx     sync.RWMutex
+	entries map[key][]registry.AuditEntry
+}
+
+// key identifies a customer across tenants
+type key struct {
+	tenant string
+	id     uint32
+}
+
+func keyOf(ctx context.Context, id uint32) key {
+	return key{tenant: tenant.FromContext(ctx), id: id}
+}
+
+func (al *auditLog) Record(ctx context.Context, e registry.AuditEntry) error {
+	al.mtx.Lock()
+	defer al.mtx.Unlock()
+
+	k := keyOf(ctx, e.CustomerID)
+	al.entries[k] = append(al.entries[k], e)
+
+	return nil
+}
+
+func (al *auditLog) Entries(ctx context.Context, id uint32) ([]registry.AuditEntry, error) {
+	al.mtx.RLock()
+	defer al.mtx.RUnlock()
+
+	return append([]registry.AuditEntry(nil), al.entries[keyOf(ctx, id)]...), nil
+}
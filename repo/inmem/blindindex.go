@@ -0,0 +1,119 @@
+package inmem
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/nacobas/customer/tenant"
+)
+
+func NewBlindIndex() *BlindIndex {
+	return &BlindIndex{
+		mtx:    sync.RWMutex{},
+		hashes: map[hashKey]uint32{},
+		byID:   map[idKey]string{},
+	}
+}
+
+type hashKey struct {
+	tenant, kind, hash string
+}
+
+type idKey struct {
+	key
+	kind string
+}
+
+// BlindIndex is partitioned by tenant so the same identifier may be used by each tenant
+type BlindIndex struct {
+	mtx    sync.RWMutex
+	hashes map[hashKey]uint32
+	byID   map[idKey]string
+}
+
+func (bi *BlindIndex) Put(ctx context.Context, kind, hash string, id uint32) error {
+	bi.mtx.Lock()
+	defer bi.mtx.Unlock()
+
+	t := tenant.FromContext(ctx)
+	ik := idKey{keyOf(ctx, id), kind}
+
+	if old, ok := bi.byID[ik]; ok {
+		bi.unmap(hashKey{t, kind, old}, id)
+	}
+
+	bi.hashes[hashKey{t, kind, hash}] = id
+	bi.byID[ik] = hash
+
+	return nil
+}
+
+func (bi *BlindIndex) Lookup(ctx context.Context, kind, hash string) (uint32, bool, error) {
+	bi.mtx.RLock()
+	defer bi.mtx.RUnlock()
+
+	id, ok := bi.hashes[hashKey{tenant.FromContext(ctx), kind, hash}]
+
+	return id, ok, nil
+}
+
+func (bi *BlindIndex) Delete(ctx context.Context, kind string, id uint32) error {
+	bi.mtx.Lock()
+	defer bi.mtx.Unlock()
+
+	ik := idKey{keyOf(ctx, id), kind}
+
+	if old, ok := bi.byID[ik]; ok {
+		bi.unmap(hashKey{tenant.FromContext(ctx), kind, old}, id)
+		delete(bi.byID, ik)
+	}
+
+	return nil
+}
+
+// unmap removes hk unless it has been mapped to another customer since
+func (bi *BlindIndex) unmap(hk hashKey, id uint32) {
+	if bi.hashes[hk] == id {
+		delete(bi.hashes, hk)
+	}
+}
+
+func (bi *BlindIndex) IDs(ctx context.Context) ([]uint32, error) {
+	bi.mtx.RLock()
+	defer bi.mtx.RUnlock()
+
+	t := tenant.FromContext(ctx)
+	seen := map[uint32]bool{}
+	ids := []uint32{}
+
+	for k := range bi.byID {
+		if k.tenant == t && !seen[k.id] {
+			seen[k.id] = true
+			ids = append(ids, k.id)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids, nil
+}
+
+func (bi *BlindIndex) Tenants(ctx context.Context) ([]string, error) {
+	bi.mtx.RLock()
+	defer bi.mtx.RUnlock()
+
+	seen := map[string]bool{}
+	ts := []string{}
+
+	for k := range bi.byID {
+		if !seen[k.tenant] {
+			seen[k.tenant] = true
+			ts = append(ts, k.tenant)
+		}
+	}
+
+	sort.Strings(ts)
+
+	return ts, nil
+}
@@ -0,0 +1,58 @@
+package inmem
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/registry"
+)
+
+func NewInfoHistory() registry.InfoHistory {
+	return &infoHistory{
+		mtx:      sync.RWMutex{},
+		versions: map[key][]registry.InfoVersion{},
+	}
+}
+
+type infoHistory struct {
+	mtx      sync.RWMutex
+	versions map[key][]registry.InfoVersion
+}
+
+func (h *infoHistory) Versions(ctx context.Context, id uint32) ([]registry.InfoVersion, error) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	return append([]registry.InfoVersion(nil), h.versions[keyOf(ctx, id)]...), nil
+}
+
+func (h *infoHistory) Record(ctx context.Context, id uint32, prev customer.Info, v registry.InfoVersion) error {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	k := keyOf(ctx, id)
+
+	vs := h.versions[k]
+	if len(vs) == 0 && prev != nil {
+		vs = []registry.InfoVersion{{Info: prev}}
+	}
+
+	superseded, recorded := registry.Correct(vs, v)
+	for _, i := range superseded {
+		vs[i].SupersededAt = v.RecordedAt
+	}
+
+	h.versions[k] = append(vs, recorded...)
+
+	return nil
+}
+
+func (h *infoHistory) Purge(ctx context.Context, id uint32) error {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	delete(h.versions, keyOf(ctx, id))
+
+	return nil
+}
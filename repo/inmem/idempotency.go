@@ -0,0 +1,108 @@
+package inmem
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nacobas/customer/registry"
+	"github.com/nacobas/customer/tenant"
+)
+
+// NewIdempotencyStore remembers idempotency keys per tenant for ttl. Expired keys are ignored
+// on lookup and dropped in claim order by later Claims.
+func NewIdempotencyStore(ttl time.Duration) registry.IdempotencyStore {
+	return &idempotencyStore{
+		ttl:     ttl,
+		now:     time.Now,
+		records: map[idempotencyKey]idempotencyRecord{},
+	}
+}
+
+type idempotencyStore struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mtx     sync.Mutex
+	records map[idempotencyKey]idempotencyRecord
+	// claims are in claim order, which is expiry order as every key has the same ttl
+	claims []claim
+}
+
+type claim struct {
+	key     idempotencyKey
+	expires time.Time
+}
+
+type idempotencyKey struct {
+	tenant string
+	key    string
+}
+
+type idempotencyRecord struct {
+	registry.IdempotencyRecord
+	expires time.Time
+}
+
+func (is *idempotencyStore) Claim(ctx context.Context, key, fingerprint string) (registry.IdempotencyRecord, bool, error) {
+	is.mtx.Lock()
+	defer is.mtx.Unlock()
+
+	now := is.now()
+	is.expire(now)
+
+	k := idempotencyKey{tenant: tenant.FromContext(ctx), key: key}
+
+	if r, ok := is.records[k]; ok && !now.After(r.expires) {
+		return r.IdempotencyRecord, false, nil
+	}
+
+	expires := now.Add(is.ttl)
+	is.records[k] = idempotencyRecord{
+		IdempotencyRecord: registry.IdempotencyRecord{Fingerprint: fingerprint},
+		expires:           expires,
+	}
+	is.claims = append(is.claims, claim{key: k, expires: expires})
+
+	return registry.IdempotencyRecord{Fingerprint: fingerprint}, true, nil
+}
+
+// expire drops the records expired at now from the front of claims, so each claim is visited
+// once. Claims of released or reclaimed keys no longer match their record and are skipped.
+func (is *idempotencyStore) expire(now time.Time) {
+	n := 0
+	for ; n < len(is.claims) && now.After(is.claims[n].expires); n++ {
+		c := is.claims[n]
+		if r, ok := is.records[c.key]; ok && r.expires.Equal(c.expires) {
+			delete(is.records, c.key)
+		}
+	}
+
+	is.claims = is.claims[n:]
+}
+
+func (is *idempotencyStore) Complete(ctx context.Context, key string, id uint32) error {
+	is.mtx.Lock()
+	defer is.mtx.Unlock()
+
+	k := idempotencyKey{tenant: tenant.FromContext(ctx), key: key}
+
+	r, ok := is.records[k]
+	if !ok {
+		return ErrNotFound
+	}
+
+	r.CustomerID = id
+	is.records[k] = r
+
+	return nil
+}
+
+func (is *idempotencyStore) Release(ctx context.Context, key string) error {
+	is.mtx.Lock()
+	defer is.mtx.Unlock()
+
+	delete(is.records, idempotencyKey{tenant: tenant.FromContext(ctx), key: key})
+
+	return nil
+}
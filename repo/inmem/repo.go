@@ -2,11 +2,14 @@ package inmem
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/cockroachdb/errors"
 	"github.com/nacobas/customer/customer"
 	"github.com/nacobas/customer/registry"
+	"github.com/nacobas/customer/tenant"
 )
 
 var (
@@ -18,10 +21,11 @@ var (
 func NewRepo() registry.Repo {
 	return &repo{
 		mtx:  sync.RWMutex{},
-		data: map[uint32]customer.Customer{},
+		data: map[string]map[uint32]customer.Customer{},
 	}
 }
 
+// NewRepoWithSeed returns a repo with seed stored for the default tenant
 func NewRepoWithSeed(seed []customer.Customer) registry.Repo {
 
 	var data = map[uint32]customer.Customer{}
@@ -32,13 +36,14 @@ func NewRepoWithSeed(seed []customer.Customer) registry.Repo {
 
 	return &repo{
 		mtx:  sync.RWMutex{},
-		data: data,
+		data: map[string]map[uint32]customer.Customer{tenant.Default: data},
 	}
 }
 
 type repo struct {
-	mtx  sync.RWMutex
-	data map[uint32]customer.Customer
+	mtx sync.RWMutex
+	// customers partitioned by tenant
+	data map[string]map[uint32]customer.Customer
 }
 
 func (r *repo) Get(ctx context.Context, id uint32) (*customer.Customer, error) {
@@ -47,7 +52,7 @@ func (r *repo) Get(ctx context.Context, id uint32) (*customer.Customer, error) {
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
 
-	c, ok := r.data[id]
+	c, ok := r.data[tenant.FromContext(ctx)][id]
 	if !ok {
 		return nil, errors.Wrap(registry.ErrNotFound, op)
 	}
@@ -61,28 +66,236 @@ func (r *repo) Insert(ctx context.Context, c *customer.Customer) error {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
 
-	_, ok := r.data[c.ID]
+	data := r.partition(ctx)
+
+	_, ok := data[c.ID]
 	if ok {
 		return errors.Wrap(ErrUsedID, op)
 	}
 
-	r.data[c.ID] = *c
+	if conflicts(data, c) {
+		return errors.Mark(errors.Wrap(ErrConflict, op), registry.ErrConflict)
+	}
+
+	data[c.ID] = *c
 
 	return nil
 }
 
 func (r *repo) Update(ctx context.Context, c *customer.Customer) error {
-	const op string = "inmem.repo.Update"
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return update(r.partition(ctx), c)
+}
+
+func (r *repo) BatchGet(ctx context.Context, ids []uint32) ([]*customer.Customer, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	data := r.data[tenant.FromContext(ctx)]
 
+	found := make([]*customer.Customer, len(ids))
+	for i, id := range ids {
+		if c, ok := data[id]; ok {
+			found[i] = &c
+		}
+	}
+
+	return found, nil
+}
+
+func (r *repo) BatchUpdate(ctx context.Context, cs []*customer.Customer) ([]error, error) {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
 
-	_, ok := r.data[c.ID]
+	data := r.partition(ctx)
+
+	errs := make([]error, len(cs))
+	for i, c := range cs {
+		errs[i] = update(data, c)
+	}
+
+	return errs, nil
+}
+
+// update must be called with write lock held
+func update(data map[uint32]customer.Customer, c *customer.Customer) error {
+	const op string = "inmem.repo.Update"
+
+	_, ok := data[c.ID]
 	if !ok {
-		return errors.Wrap(ErrNotFound, op)
+		return errors.Mark(errors.Wrap(ErrNotFound, op), registry.ErrNotFound)
 	}
 
-	r.data[c.ID] = *c
+	if conflicts(data, c) {
+		return errors.Mark(errors.Wrap(ErrConflict, op), registry.ErrConflict)
+	}
+
+	data[c.ID] = *c
+
+	return nil
+}
+
+func (r *repo) FindBySSN(ctx context.Context, ssn string) ([]*customer.Customer, error) {
+	return r.findBySSN(ctx, ssn, false), nil
+}
+
+func (r *repo) FindMergedBySSN(ctx context.Context, ssn string) ([]*customer.Customer, error) {
+	return r.findBySSN(ctx, ssn, true), nil
+}
+
+func (r *repo) findBySSN(ctx context.Context, ssn string, merged bool) []*customer.Customer {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	var found []*customer.Customer
+
+	ssn = customer.CanonicalID(ssn)
+
+	data := r.data[tenant.FromContext(ctx)]
+	for id := range data {
+		c := data[id]
+		if pi, ok := customer.PersonOf(c.Info); ok && (c.MergedInto != 0) == merged && customer.CanonicalID(pi.SSN) == ssn {
+			found = append(found, &c)
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].ID < found[j].ID })
+
+	return found
+}
+
+func (r *repo) List(ctx context.Context, after uint32, limit int) ([]*customer.Customer, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
 
+	data := r.data[tenant.FromContext(ctx)]
+
+	ids := make([]uint32, 0, len(data))
+	for id := range data {
+		if id > after {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	found := make([]*customer.Customer, 0, len(ids))
+	for _, id := range ids {
+		c := data[id]
+		found = append(found, &c)
+	}
+
+	return found, nil
+}
+
+func (r *repo) Search(ctx context.Context, query string, limit int) ([]*customer.Customer, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	ident, name := customer.CanonicalID(query), customer.SearchKey(query)
+
+	var found []*customer.Customer
+
+	data := r.data[tenant.FromContext(ctx)]
+	for id := range data {
+		c := data[id]
+		if !c.Erased && c.MergedInto == 0 && matches(&c, ident, name) {
+			found = append(found, &c)
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].ID < found[j].ID })
+
+	if len(found) > limit {
+		found = found[:limit]
+	}
+
+	return found, nil
+}
+
+// matches compares canonical identifiers exactly and transliterated names by substring
+func matches(c *customer.Customer, ident, name string) bool {
+	if c.Info == nil {
+		return false
+	}
+	for _, id := range identifiers(c) {
+		if id == ident {
+			return true
+		}
+	}
+	return strings.Contains(customer.Transliterate(c.CanonicalName()), name)
+}
+
+// partition returns the customers of the request tenant, must be called with write lock held
+func (r *repo) partition(ctx context.Context) map[uint32]customer.Customer {
+	t := tenant.FromContext(ctx)
+
+	data, ok := r.data[t]
+	if !ok {
+		data = map[uint32]customer.Customer{}
+		r.data[t] = data
+	}
+
+	return data
+}
+
+// conflicts reports whether another customer of the tenant shares a unique key with c,
+// redirects of merged customers are ignored as they may duplicate their survivor
+func conflicts(data map[uint32]customer.Customer, c *customer.Customer) bool {
+	if c.MergedInto != 0 {
+		return false
+	}
+
+	keys := uniqueKeys(c)
+
+	for id := range data {
+		o := data[id]
+		if id == c.ID || o.MergedInto != 0 {
+			continue
+		}
+		for k := range uniqueKeys(&o) {
+			if keys[k] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// uniqueKeys returns the keys no two customers may share. Sole traders share business IDs
+// with the legal IDs of organizations, and a person may be both a private customer and a
+// sole trader but not two sole traders.
+func uniqueKeys(c *customer.Customer) map[string]bool {
+	switch i := c.Info.(type) {
+	case *customer.PersonInfo:
+		return map[string]bool{"ssn:" + customer.CanonicalID(i.SSN): true}
+	case *customer.OrganizationInfo:
+		return map[string]bool{"legal-id:" + customer.CanonicalID(i.LeagalID): true}
+	case *customer.SoleTraderInfo:
+		return map[string]bool{
+			"legal-id:" + customer.CanonicalID(i.BusinessID): true,
+			"trader-ssn:" + customer.CanonicalID(i.SSN):      true,
+		}
+	}
+	return nil
+}
+
+// identifiers returns the canonical SSN, legal ID and business ID of c matched by search
+func identifiers(c *customer.Customer) []string {
+	switch i := c.Info.(type) {
+	case *customer.PersonInfo:
+		return []string{customer.CanonicalID(i.SSN)}
+	case *customer.OrganizationInfo:
+		return []string{customer.CanonicalID(i.LeagalID)}
+	case *customer.SoleTraderInfo:
+		return []string{customer.CanonicalID(i.SSN), customer.CanonicalID(i.BusinessID)}
+	}
 	return nil
 }
@@ -0,0 +1,170 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+)
+
+var (
+	ErrScreening = errors.New("Screening failed")
+)
+
+// score thresholds for Medium and High ratings
+const (
+	mediumThreshold = 20
+	highThreshold   = 50
+)
+
+// points added per rated attribute
+var points = map[customer.RiskRating]int{
+	customer.LowRisk:    0,
+	customer.MediumRisk: 20,
+	customer.HighRisk:   50,
+}
+
+// Screener checks a customer against sanction, PEP or adverse media lists
+type Screener interface {
+	Hits(ctx context.Context, c *customer.Customer) (int, error)
+}
+
+type Option func(*Engine)
+
+// WithCountryRisk overrides the country risk table, keys are ISO 3166-1 alpha-2 codes
+func WithCountryRisk(table map[string]customer.RiskRating) Option {
+	return func(e *Engine) {
+		e.countries = table
+	}
+}
+
+// WithFormRisk overrides the legal form risk table, keys are case insensitive
+func WithFormRisk(table map[string]customer.RiskRating) Option {
+	return func(e *Engine) {
+		e.forms = normaliseKeys(table)
+	}
+}
+
+func WithScreener(s Screener) Option {
+	return func(e *Engine) {
+		e.screener = s
+	}
+}
+
+func WithClock(now func() time.Time) Option {
+	return func(e *Engine) {
+		e.now = now
+	}
+}
+
+func NewEngine(opts ...Option) *Engine {
+	e := &Engine{
+		countries: DefaultCountryRisk,
+		forms:     normaliseKeys(DefaultFormRisk),
+		now:       time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Engine is a rules based risk scoring engine. Every rule adds points and an explanation,
+// the total is mapped to a customer.RiskRating.
+type Engine struct {
+	countries map[string]customer.RiskRating
+	forms     map[string]customer.RiskRating
+	screener  Screener
+	now       func() time.Time
+}
+
+type assessment struct {
+	score   int
+	reasons []string
+}
+
+func (a *assessment) add(p int, format string, args ...interface{}) {
+	a.score += p
+	a.reasons = append(a.reasons, fmt.Sprintf(format, args...))
+}
+
+func (e *Engine) Score(ctx context.Context, c *customer.Customer) (customer.Risk, error) {
+	const op string = "risk.Engine.Score"
+
+	a := &assessment{}
+
+	switch i := c.Info.(type) {
+	case *customer.PersonInfo:
+		e.country(a, "citizenship", i.Citizenship)
+	case *customer.SoleTraderInfo:
+		e.country(a, "citizenship", i.Citizenship)
+	case *customer.OrganizationInfo:
+		e.country(a, "registration country", i.RegistrationCountry)
+		e.orgAge(a, i.RegistrationDate.ToTime())
+		e.form(a, i.Form)
+	default:
+		return customer.Risk{}, errors.Newf("%s: unsupported customer info %T", op, c.Info)
+	}
+
+	if e.screener != nil {
+		hits, err := e.screener.Hits(ctx, c)
+		if err != nil {
+			return customer.Risk{}, errors.Mark(errors.Wrap(err, op), ErrScreening)
+		}
+		if hits > 0 {
+			a.add(points[customer.HighRisk], "%d screening hit(s)", hits)
+		}
+	}
+
+	return customer.Risk{Rating: rating(a.score), Reasons: a.reasons}, nil
+}
+
+func (e *Engine) country(a *assessment, field, code string) {
+	r, ok := e.countries[strings.ToUpper(code)]
+	if !ok || r == customer.LowRisk {
+		return
+	}
+	a.add(points[r], "%s %s is %s risk", field, code, r)
+}
+
+func (e *Engine) orgAge(a *assessment, registered time.Time) {
+	age := e.now().Sub(registered)
+
+	switch {
+	case age < 365*24*time.Hour:
+		a.add(points[customer.HighRisk], "organization registered less than a year ago")
+	case age < 3*365*24*time.Hour:
+		a.add(points[customer.MediumRisk], "organization registered less than three years ago")
+	}
+}
+
+func (e *Engine) form(a *assessment, form string) {
+	r, ok := e.forms[strings.ToLower(form)]
+	if !ok || r == customer.LowRisk {
+		return
+	}
+	a.add(points[r], "legal form %s is %s risk", form, r)
+}
+
+func rating(score int) customer.RiskRating {
+	switch {
+	case score >= highThreshold:
+		return customer.HighRisk
+	case score >= mediumThreshold:
+		return customer.MediumRisk
+	}
+	return customer.LowRisk
+}
+
+func normaliseKeys(table map[string]customer.RiskRating) map[string]customer.RiskRating {
+	n := make(map[string]customer.RiskRating, len(table))
+	for k, v := range table {
+		n[strings.ToLower(k)] = v
+	}
+	return n
+}
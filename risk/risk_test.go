@@ -0,0 +1,131 @@
+package risk_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/risk"
+	"github.com/stretchr/testify/assert"
+)
+
+type screener int
+
+func (s screener) Hits(ctx context.Context, c *customer.Customer) (int, error) {
+	if s < 0 {
+		return 0, errors.New("screening service down")
+	}
+	return int(s), nil
+}
+
+func TestScore(t *testing.T) {
+	t.Parallel()
+
+	now := func() time.Time { return time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC) }
+
+	testCases := []struct {
+		desc    string
+		info    customer.Info
+		hits    screener
+		want    customer.RiskRating
+		reasons int
+		err     error
+	}{
+		{
+			desc:    "low risk person",
+			info:    testPerson(t, "FI"),
+			want:    customer.LowRisk,
+			reasons: 0,
+		},
+		{
+			desc:    "medium risk citizenship",
+			info:    testPerson(t, "NG"),
+			want:    customer.MediumRisk,
+			reasons: 1,
+		},
+		{
+			desc:    "high risk citizenship",
+			info:    testPerson(t, "KP"),
+			want:    customer.HighRisk,
+			reasons: 1,
+		},
+		{
+			desc:    "person with screening hit",
+			info:    testPerson(t, "FI"),
+			hits:    2,
+			want:    customer.HighRisk,
+			reasons: 1,
+		},
+		{
+			desc:    "established org",
+			info:    testOrg(t, "Ltd", "2000-01-01"),
+			want:    customer.LowRisk,
+			reasons: 0,
+		},
+		{
+			desc:    "two year old org",
+			info:    testOrg(t, "Ltd", "2019-01-01"),
+			want:    customer.MediumRisk,
+			reasons: 1,
+		},
+		{
+			desc:    "new trust",
+			info:    testOrg(t, "Trust", "2021-01-01"),
+			want:    customer.HighRisk,
+			reasons: 2,
+		},
+		{
+			desc: "screening failure",
+			info: testPerson(t, "FI"),
+			hits: -1,
+			err:  risk.ErrScreening,
+		},
+	}
+	for i := range testCases {
+		tC := testCases[i]
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			e := risk.NewEngine(risk.WithClock(now), risk.WithScreener(tC.hits))
+
+			got, err := e.Score(context.Background(), customer.New(1, tC.info))
+
+			if tC.err == nil {
+				assert.Nil(t, err, "error should be nil")
+				assert.Equal(t, tC.want, got.Rating, "risk rating should equal")
+				assert.Len(t, got.Reasons, tC.reasons, "every rule hit should be explained")
+			} else {
+				assert.True(t, errors.Is(err, tC.err), "Expected error should be found in the chain")
+			}
+		})
+	}
+}
+
+func testPerson(t *testing.T, citizenship string) *customer.PersonInfo {
+	return &customer.PersonInfo{
+		GivenName:   "given-name",
+		FamilyName:  "family-name",
+		SSN:         "SSN",
+		DateOfBirth: parseDate(t, "1970-01-01"),
+		Citizenship: citizenship}
+}
+
+func testOrg(t *testing.T, form, registered string) *customer.OrganizationInfo {
+	return &customer.OrganizationInfo{
+		Name:                "org-name",
+		Form:                form,
+		LeagalID:            "legal-id",
+		RegistrationDate:    parseDate(t, registered),
+		RegistrationCountry: "US"}
+}
+
+func parseDate(t *testing.T, datestr string) date.Date {
+	d, err := date.ParseDate(datestr)
+	if err != nil {
+		t.Fatalf("Failed to parse date from: %s, error: %v", datestr, err)
+	}
+	return d
+}
@@ -0,0 +1,32 @@
+package risk
+
+import "github.com/nacobas/customer/customer"
+
+// DefaultCountryRisk rates countries that are not Low risk. Compliance is expected to
+// maintain its own table and pass it with WithCountryRisk.
+var DefaultCountryRisk = map[string]customer.RiskRating{
+	// jurisdictions subject to a call for action
+	"IR": customer.HighRisk,
+	"KP": customer.HighRisk,
+	"MM": customer.HighRisk,
+	// jurisdictions under increased monitoring
+	"BF": customer.MediumRisk,
+	"CM": customer.MediumRisk,
+	"CD": customer.MediumRisk,
+	"HT": customer.MediumRisk,
+	"ML": customer.MediumRisk,
+	"MZ": customer.MediumRisk,
+	"NG": customer.MediumRisk,
+	"SS": customer.MediumRisk,
+	"SY": customer.MediumRisk,
+	"VN": customer.MediumRisk,
+	"YE": customer.MediumRisk,
+}
+
+// DefaultFormRisk rates legal forms that are not Low risk
+var DefaultFormRisk = map[string]customer.RiskRating{
+	"trust":       customer.HighRisk,
+	"foundation":  customer.MediumRisk,
+	"partnership": customer.MediumRisk,
+	"association": customer.MediumRisk,
+}
@@ -0,0 +1,18 @@
+package tenant
+
+import "context"
+
+// Default is the tenant of requests without a tenant, used by single tenant deployments
+const Default = ""
+
+type tenantKey struct{}
+
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// FromContext returns the tenant of the request or Default
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(tenantKey{}).(string)
+	return id
+}
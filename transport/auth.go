@@ -0,0 +1,100 @@
+package transport
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Authenticator returns the principal of a request or auth.ErrNoCredentials when the
+// request carries no credentials it understands
+type Authenticator func(ctx context.Context) (*auth.Principal, error)
+
+// BearerAuthenticator verifies a JWT from the authorization metadata
+func BearerAuthenticator(v *auth.JWTVerifier) Authenticator {
+	return func(ctx context.Context) (*auth.Principal, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		for _, h := range md.Get("authorization") {
+			if len(h) > 7 && strings.EqualFold(h[:7], "bearer ") {
+				return v.Verify(h[7:])
+			}
+		}
+
+		return nil, auth.ErrNoCredentials
+	}
+}
+
+// MTLSAuthenticator maps a verified client certificate to a principal
+func MTLSAuthenticator() Authenticator {
+	return func(ctx context.Context) (*auth.Principal, error) {
+		p, ok := peer.FromContext(ctx)
+		if !ok {
+			return nil, auth.ErrNoCredentials
+		}
+
+		tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+		if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+			return nil, auth.ErrNoCredentials
+		}
+
+		return auth.CertPrincipal(tlsInfo.State.VerifiedChains[0][0])
+	}
+}
+
+// UnaryAuthInterceptor puts the principal of the first authenticator accepting the request
+// into the context. Requests without credentials pass through, the registry decides whether
+// they are allowed.
+func UnaryAuthInterceptor(as ...Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, as)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func StreamAuthInterceptor(as ...Authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), as)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *wrappedStream) Context() context.Context {
+	return s.ctx
+}
+
+func authenticate(ctx context.Context, as []Authenticator) (context.Context, error) {
+	for _, a := range as {
+		p, err := a(ctx)
+		if errors.Is(err, auth.ErrNoCredentials) {
+			continue
+		}
+		if err != nil {
+			return ctx, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return auth.NewContext(ctx, p), nil
+	}
+
+	return ctx, nil
+}
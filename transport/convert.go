@@ -0,0 +1,325 @@
+package transport
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/pb"
+	"github.com/nacobas/customer/registry"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	ErrMissingInfo = errors.New("Customer info missing")
+)
+
+// ToPBCustomer converts a customer for the wire, it is shared with the client package
+func ToPBCustomer(c *customer.Customer) *pb.Customer {
+	pc := &pb.Customer{
+		Id:         c.ID,
+		State:      pb.State(c.State - 1),
+		Risk:       ToPBRisk(c.Risk),
+		Erased:     c.Erased,
+		MergedInto: c.MergedInto,
+	}
+
+	if c.Profile != (customer.ProfileRef{}) {
+		pc.ValidationProfile = &pb.ValidationProfile{Name: c.Profile.Name, Version: c.Profile.Version}
+	}
+
+	switch i := c.Info.(type) {
+	case *customer.PersonInfo:
+		pc.Info = &pb.Customer_PersonInfo{PersonInfo: ToPBPersonInfo(i)}
+	case *customer.OrganizationInfo:
+		pc.Info = &pb.Customer_OrganizationInfo{OrganizationInfo: ToPBOrganizationInfo(i)}
+	case *customer.SoleTraderInfo:
+		pc.Info = &pb.Customer_SoleTraderInfo{SoleTraderInfo: ToPBSoleTraderInfo(i)}
+	}
+
+	return pc
+}
+
+func ToPBRisk(r customer.Risk) *pb.Risk {
+	return &pb.Risk{
+		Rating:  pb.RiskRating(r.Rating),
+		Reasons: r.Reasons,
+	}
+}
+
+func ToPBPersonInfo(i *customer.PersonInfo) *pb.PersonInfo {
+	return &pb.PersonInfo{
+		GivenName:        i.GivenName,
+		MiddleNames:      i.MiddleNames,
+		FamilyNamePrefix: i.FamilyNamePrefix,
+		FamilyName:       i.FamilyName,
+		Ssn:              i.SSN,
+		DateOfBirth:      i.DateOfBirth.String(),
+		BirthDate:        ToPBDate(i.DateOfBirth),
+		Citizenship:      i.Citizenship,
+	}
+}
+
+func ToPBOrganizationInfo(i *customer.OrganizationInfo) *pb.OrganizationInfo {
+	return &pb.OrganizationInfo{
+		Name:                i.Name,
+		Form:                i.Form,
+		LegalId:             i.LeagalID,
+		DateOfRegistration:  i.RegistrationDate.String(),
+		RegistrationDate:    ToPBDate(i.RegistrationDate),
+		RegistrationCountry: i.RegistrationCountry,
+	}
+}
+
+func ToPBSoleTraderInfo(i *customer.SoleTraderInfo) *pb.SoleTraderInfo {
+	return &pb.SoleTraderInfo{
+		GivenName:        i.GivenName,
+		MiddleNames:      i.MiddleNames,
+		FamilyNamePrefix: i.FamilyNamePrefix,
+		FamilyName:       i.FamilyName,
+		Ssn:              i.SSN,
+		DateOfBirth:      i.DateOfBirth.String(),
+		BirthDate:        ToPBDate(i.DateOfBirth),
+		Citizenship:      i.Citizenship,
+		TradeName:        i.TradeName,
+		BusinessId:       i.BusinessID,
+	}
+}
+
+// FromPBCustomer converts a customer returned by the registry, fields cleared by redaction
+// are left zero
+func FromPBCustomer(pc *pb.Customer) (*customer.Customer, error) {
+	const op string = "transport.FromPBCustomer"
+
+	c := &customer.Customer{
+		ID:         pc.GetId(),
+		State:      customer.State(pc.GetState() + 1),
+		Risk:       FromPBRisk(pc.GetRisk()),
+		Erased:     pc.GetErased(),
+		MergedInto: pc.GetMergedInto(),
+		Profile: customer.ProfileRef{
+			Name:    pc.GetValidationProfile().GetName(),
+			Version: pc.GetValidationProfile().GetVersion(),
+		},
+	}
+
+	var err error
+	if c.Info, err = infoFromPB(pc); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return c, nil
+}
+
+func FromPBRisk(r *pb.Risk) customer.Risk {
+	return customer.Risk{
+		Rating:  customer.RiskRating(r.GetRating()),
+		Reasons: r.GetReasons(),
+	}
+}
+
+// infoOneof is implemented by the generated messages carrying customer info in a oneof
+type infoOneof interface {
+	GetPersonInfo() *pb.PersonInfo
+	GetOrganizationInfo() *pb.OrganizationInfo
+	GetSoleTraderInfo() *pb.SoleTraderInfo
+}
+
+// infoFromPB converts the info set in m, ErrMissingInfo when none is
+func infoFromPB(m infoOneof) (customer.Info, error) {
+	switch {
+	case m.GetPersonInfo() != nil:
+		return FromPBPersonInfo(m.GetPersonInfo())
+	case m.GetOrganizationInfo() != nil:
+		return FromPBOrganizationInfo(m.GetOrganizationInfo())
+	case m.GetSoleTraderInfo() != nil:
+		return FromPBSoleTraderInfo(m.GetSoleTraderInfo())
+	}
+	return nil, ErrMissingInfo
+}
+
+// fromPBRequestInfo converts the info of a request, a request without info fails validation.
+// With an update mask fields outside the mask may be left empty.
+func fromPBRequestInfo(req infoOneof) (customer.Info, error) {
+	const op string = "transport.fromPBRequestInfo"
+
+	i, err := infoFromPB(req)
+	if errors.Is(err, ErrMissingInfo) {
+		return nil, errors.Mark(errors.Wrap(err, op), registry.ErrValidation)
+	}
+	return i, err
+}
+
+// ToPBInfoVersion converts a version of customer info for the wire
+func ToPBInfoVersion(v *registry.InfoVersion) *pb.InfoVersion {
+	pv := &pb.InfoVersion{
+		ValidFrom:    ToPBTime(v.ValidFrom),
+		ValidTo:      ToPBTime(v.ValidTo),
+		RecordedAt:   ToPBTime(v.RecordedAt),
+		SupersededAt: ToPBTime(v.SupersededAt),
+	}
+
+	switch i := v.Info.(type) {
+	case *customer.PersonInfo:
+		pv.Info = &pb.InfoVersion_PersonInfo{PersonInfo: ToPBPersonInfo(i)}
+	case *customer.OrganizationInfo:
+		pv.Info = &pb.InfoVersion_OrganizationInfo{OrganizationInfo: ToPBOrganizationInfo(i)}
+	case *customer.SoleTraderInfo:
+		pv.Info = &pb.InfoVersion_SoleTraderInfo{SoleTraderInfo: ToPBSoleTraderInfo(i)}
+	}
+
+	return pv
+}
+
+// FromPBInfoVersion converts a version returned by the registry, fields cleared by redaction
+// are left zero
+func FromPBInfoVersion(pv *pb.InfoVersion) (*registry.InfoVersion, error) {
+	const op string = "transport.FromPBInfoVersion"
+
+	v := &registry.InfoVersion{
+		ValidFrom:    FromPBTime(pv.GetValidFrom()),
+		ValidTo:      FromPBTime(pv.GetValidTo()),
+		RecordedAt:   FromPBTime(pv.GetRecordedAt()),
+		SupersededAt: FromPBTime(pv.GetSupersededAt()),
+	}
+
+	var err error
+	if v.Info, err = infoFromPB(pv); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return v, nil
+}
+
+func ToPBDuplicate(d registry.Duplicate) *pb.Duplicate {
+	return &pb.Duplicate{
+		CustomerId:  d.ID,
+		DuplicateId: d.OtherID,
+		Score:       d.Score,
+		Reasons:     d.Reasons,
+	}
+}
+
+func FromPBDuplicate(d *pb.Duplicate) registry.Duplicate {
+	return registry.Duplicate{
+		ID:      d.GetCustomerId(),
+		OtherID: d.GetDuplicateId(),
+		Score:   d.GetScore(),
+		Reasons: d.GetReasons(),
+	}
+}
+
+// ToPBTime converts zero time to an unset timestamp
+func ToPBTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// FromPBTime converts an unset timestamp to zero time
+func FromPBTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// maskFields are the domain names of proto info fields whose names differ, the deprecated
+// string dates included
+var maskFields = map[string]string{
+	"birth_date":           "date_of_birth",
+	"date_of_registration": "registration_date",
+}
+
+// pbMaskFields are the proto names of domain fields whose names differ
+var pbMaskFields = map[string]string{
+	"date_of_birth": "birth_date",
+}
+
+// ToPBUpdateMask returns the update mask of fields named by their domain JSON names
+func ToPBUpdateMask(fields []string) *fieldmaskpb.FieldMask {
+	paths := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if p, ok := pbMaskFields[f]; ok {
+			f = p
+		}
+		paths = append(paths, f)
+	}
+
+	return &fieldmaskpb.FieldMask{Paths: paths}
+}
+
+// fromPBUpdateMask returns the fields of the mask by their domain JSON names, unknown paths
+// are passed on for the registry to reject
+func fromPBUpdateMask(req *pb.UpdateInfoRequest) []string {
+	paths := req.GetUpdateMask().GetPaths()
+
+	fields := make([]string, 0, len(paths))
+	for _, p := range paths {
+		if f, ok := maskFields[p]; ok {
+			p = f
+		}
+		fields = append(fields, p)
+	}
+
+	return fields
+}
+
+func FromPBPersonInfo(i *pb.PersonInfo) (*customer.PersonInfo, error) {
+	const op string = "transport.FromPBPersonInfo"
+
+	dob, err := wireDate("birth_date", i.GetBirthDate(), i.GetDateOfBirth())
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), registry.ErrValidation)
+	}
+
+	return &customer.PersonInfo{
+		GivenName:        i.GetGivenName(),
+		MiddleNames:      i.GetMiddleNames(),
+		FamilyNamePrefix: i.GetFamilyNamePrefix(),
+		FamilyName:       i.GetFamilyName(),
+		SSN:              i.GetSsn(),
+		DateOfBirth:      dob,
+		Citizenship:      i.GetCitizenship(),
+	}, nil
+}
+
+func FromPBSoleTraderInfo(i *pb.SoleTraderInfo) (*customer.SoleTraderInfo, error) {
+	const op string = "transport.FromPBSoleTraderInfo"
+
+	dob, err := wireDate("birth_date", i.GetBirthDate(), i.GetDateOfBirth())
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), registry.ErrValidation)
+	}
+
+	return &customer.SoleTraderInfo{
+		GivenName:        i.GetGivenName(),
+		MiddleNames:      i.GetMiddleNames(),
+		FamilyNamePrefix: i.GetFamilyNamePrefix(),
+		FamilyName:       i.GetFamilyName(),
+		SSN:              i.GetSsn(),
+		DateOfBirth:      dob,
+		Citizenship:      i.GetCitizenship(),
+		TradeName:        i.GetTradeName(),
+		BusinessID:       i.GetBusinessId(),
+	}, nil
+}
+
+func FromPBOrganizationInfo(i *pb.OrganizationInfo) (*customer.OrganizationInfo, error) {
+	const op string = "transport.FromPBOrganizationInfo"
+
+	rd, err := wireDate("registration_date", i.GetRegistrationDate(), i.GetDateOfRegistration())
+	if err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), registry.ErrValidation)
+	}
+
+	return &customer.OrganizationInfo{
+		Name:                i.GetName(),
+		Form:                i.GetForm(),
+		LeagalID:            i.GetLegalId(),
+		RegistrationDate:    rd,
+		RegistrationCountry: i.GetRegistrationCountry(),
+	}, nil
+}
@@ -0,0 +1,85 @@
+package transport
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/cockroachdb/errors"
+	gdate "google.golang.org/genproto/googleapis/type/date"
+)
+
+var ErrInvalidDate = errors.New("Invalid date")
+
+// isoDate is the ISO-8601 calendar date in extended format, the only string form accepted
+var isoDate = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// ParseDate parses an ISO-8601 calendar date YYYY-MM-DD. Other ISO-8601 forms, times and
+// dates that do not exist such as 2021-02-30 are rejected.
+func ParseDate(s string) (date.Date, error) {
+	if !isoDate.MatchString(s) {
+		return date.Date{}, errors.Wrapf(ErrInvalidDate, "%q, want YYYY-MM-DD", s)
+	}
+
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return date.Date{}, errors.Wrapf(ErrInvalidDate, "%q does not exist", s)
+	}
+
+	return date.Date{Time: t}, nil
+}
+
+// ToPBDate returns d as a google.type.Date, nil for the zero date
+func ToPBDate(d date.Date) *gdate.Date {
+	if d.IsZero() {
+		return nil
+	}
+
+	return &gdate.Date{Year: int32(d.Year()), Month: int32(d.Month()), Day: int32(d.Day())}
+}
+
+// FromPBDate returns the date of a google.type.Date, nil is the zero date. Partial dates
+// without a year, month or day and dates that do not exist are rejected.
+func FromPBDate(d *gdate.Date) (date.Date, error) {
+	if d == nil {
+		return date.Date{}, nil
+	}
+
+	if d.GetYear() < 1 || d.GetYear() > 9999 {
+		return date.Date{}, errors.Wrapf(ErrInvalidDate, "year %d, want 1 to 9999", d.GetYear())
+	}
+
+	t := time.Date(int(d.GetYear()), time.Month(d.GetMonth()), int(d.GetDay()), 0, 0, 0, 0, time.UTC)
+	if int32(t.Month()) != d.GetMonth() || int32(t.Day()) != d.GetDay() {
+		return date.Date{}, errors.Wrapf(ErrInvalidDate, "%04d-%02d-%02d does not exist", d.GetYear(), d.GetMonth(), d.GetDay())
+	}
+
+	return date.Date{Time: t}, nil
+}
+
+// wireDate returns the date of a google.type.Date field or, from clients still sending the
+// deprecated string form, of its ISO-8601 string. Both forms given must agree, a date given
+// in neither is zero and left for validation.
+func wireDate(field string, d *gdate.Date, s string) (date.Date, error) {
+	if d != nil {
+		typed, err := FromPBDate(d)
+		if err != nil {
+			return date.Date{}, errors.Wrap(err, field)
+		}
+		if s != "" && s != typed.String() {
+			return date.Date{}, errors.Wrapf(ErrInvalidDate, "%s: %s differs from string form %q", field, typed, s)
+		}
+		return typed, nil
+	}
+
+	if s == "" {
+		return date.Date{}, nil
+	}
+
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return date.Date{}, errors.Wrap(err, field)
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,140 @@
+package transport_test
+
+import (
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/pb"
+	"github.com/nacobas/customer/transport"
+	"github.com/stretchr/testify/assert"
+	gdate "google.golang.org/genproto/googleapis/type/date"
+)
+
+func TestParseDate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+		s    string
+		err  bool
+	}{
+		{desc: "calendar date", s: "1970-02-28"},
+		{desc: "leap day", s: "2000-02-29"},
+		{desc: "no leap day", s: "1970-02-29", err: true},
+		{desc: "single digit month", s: "1970-2-28", err: true},
+		{desc: "basic format", s: "19700228", err: true},
+		{desc: "date time", s: "1970-02-28T00:00:00Z", err: true},
+		{desc: "ordinal date", s: "1970-059", err: true},
+		{desc: "empty", s: "", err: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			d, err := transport.ParseDate(tC.s)
+			if tC.err {
+				assert.True(t, errors.Is(err, transport.ErrInvalidDate), "error should be ErrInvalidDate")
+				return
+			}
+			assert.Nil(t, err, "error should be nil")
+			assert.Equal(t, tC.s, d.String(), "date")
+			assert.Equal(t, d, mustFromPBDate(t, transport.ToPBDate(d)), "date should survive the round trip")
+		})
+	}
+}
+
+func TestFromPBDate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+		d    *gdate.Date
+		want string
+		err  bool
+	}{
+		{desc: "nil is zero", d: nil, want: "0001-01-01"},
+		{desc: "date", d: &gdate.Date{Year: 1970, Month: 2, Day: 28}, want: "1970-02-28"},
+		{desc: "no year", d: &gdate.Date{Month: 2, Day: 28}, err: true},
+		{desc: "no day", d: &gdate.Date{Year: 1970, Month: 2}, err: true},
+		{desc: "month out of range", d: &gdate.Date{Year: 1970, Month: 13, Day: 1}, err: true},
+		{desc: "day does not exist", d: &gdate.Date{Year: 1970, Month: 2, Day: 30}, err: true},
+		{desc: "year out of range", d: &gdate.Date{Year: 10000, Month: 1, Day: 1}, err: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			d, err := transport.FromPBDate(tC.d)
+			if tC.err {
+				assert.True(t, errors.Is(err, transport.ErrInvalidDate), "error should be ErrInvalidDate")
+				return
+			}
+			assert.Nil(t, err, "error should be nil")
+			assert.Equal(t, tC.want, d.String(), "date")
+		})
+	}
+}
+
+func TestWireDates(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+		info *pb.PersonInfo
+		want string
+		err  bool
+	}{
+		{
+			desc: "typed",
+			info: &pb.PersonInfo{BirthDate: &gdate.Date{Year: 1970, Month: 1, Day: 1}},
+			want: "1970-01-01",
+		},
+		{
+			desc: "string form",
+			info: &pb.PersonInfo{DateOfBirth: "1970-01-01"},
+			want: "1970-01-01",
+		},
+		{
+			desc: "both forms agree",
+			info: &pb.PersonInfo{DateOfBirth: "1970-01-01", BirthDate: &gdate.Date{Year: 1970, Month: 1, Day: 1}},
+			want: "1970-01-01",
+		},
+		{
+			desc: "both forms differ",
+			info: &pb.PersonInfo{DateOfBirth: "1970-01-02", BirthDate: &gdate.Date{Year: 1970, Month: 1, Day: 1}},
+			err:  true,
+		},
+		{
+			desc: "neither is left for validation",
+			info: &pb.PersonInfo{},
+			want: "0001-01-01",
+		},
+		{
+			desc: "malformed string form",
+			info: &pb.PersonInfo{DateOfBirth: "01.01.1970"},
+			err:  true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			pi, err := transport.FromPBPersonInfo(tC.info)
+			if tC.err {
+				assert.True(t, errors.Is(err, transport.ErrInvalidDate), "error should be ErrInvalidDate")
+				return
+			}
+			assert.Nil(t, err, "error should be nil")
+			assert.Equal(t, tC.want, pi.DateOfBirth.String(), "date of birth")
+
+			got := transport.ToPBPersonInfo(pi)
+			if !pi.DateOfBirth.IsZero() {
+				assert.Equal(t, tC.want, got.GetDateOfBirth(), "string form should be filled for old clients")
+				assert.NotNil(t, got.GetBirthDate(), "typed date should be filled")
+			}
+		})
+	}
+}
+
+func mustFromPBDate(t *testing.T, d *gdate.Date) date.Date {
+	got, err := transport.FromPBDate(d)
+	if err != nil {
+		t.Fatalf("Failed to convert date %v: %v", d, err)
+	}
+	return got
+}
@@ -2,28 +2,328 @@ package transport
 
 import (
 	"context"
+	"encoding/json"
+	"log"
 
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/auth"
+	"github.com/nacobas/customer/customer"
 	"github.com/nacobas/customer/pb"
+	"github.com/nacobas/customer/registry"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+func NewGRPCServer(svc registry.Service, opts ...ServerOption) pb.CustomerRegistryServer {
+	gs := &grpcServer{svc: svc, log: stdLogger{}}
+
+	for _, opt := range opts {
+		opt(gs)
+	}
+
+	return gs
+}
+
+type ServerOption func(*grpcServer)
+
+// WithRedaction masks sensitive fields of returned customers by caller role
+func WithRedaction(p *RedactionPolicy) ServerOption {
+	return func(gs *grpcServer) {
+		gs.redaction = p
+	}
+}
+
+// WithErrorLog sets where internal errors are logged, they are not returned to clients.
+// Defaults to the standard logger.
+func WithErrorLog(l Logger) ServerOption {
+	return func(gs *grpcServer) {
+		gs.log = l
+	}
+}
+
+type Logger interface {
+	Errorf(format string, args ...interface{})
+}
+
+type stdLogger struct{}
+
+func (stdLogger) Errorf(format string, args ...interface{}) {
+	log.Printf("ERROR "+format, args...)
+}
+
 type grpcServer struct {
 	pb.UnimplementedCustomerRegistryServer
+	svc       registry.Service
+	redaction *RedactionPolicy
+	log       Logger
 }
 
 func (gs *grpcServer) Get(ctx context.Context, req *pb.GetRequest) (*pb.GetResponse, error) {
-	return nil, nil
+	c, err := gs.svc.Get(ctx, req.GetCustomerId())
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	return &pb.GetResponse{Customer: gs.customer(ctx, c)}, nil
 }
 
 func (gs *grpcServer) New(ctx context.Context, req *pb.NewRequest) (*pb.NewResponse, error) {
-	return nil, nil
+	i, err := fromPBRequestInfo(req)
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	c, err := gs.svc.New(registry.NewIdempotencyContext(ctx, idempotencyKey(ctx, req)), i)
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	return &pb.NewResponse{Customer: gs.customer(ctx, c)}, nil
 }
 
-func (gs *grpcServer) Update(ctx context.Context, req *pb.UpdateInfoRequest) (*pb.UpdateInfoResponse, error) {
-	return nil, nil
+func (gs *grpcServer) UpdateInfo(ctx context.Context, req *pb.UpdateInfoRequest) (*pb.UpdateInfoResponse, error) {
+	i, err := fromPBRequestInfo(req)
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	var c *customer.Customer
+	if len(req.GetUpdateMask().GetPaths()) > 0 {
+		c, err = gs.svc.PatchInfo(ctx, req.GetCustomerId(), i, fromPBUpdateMask(req))
+	} else {
+		c, err = gs.svc.UpdateInfo(ctx, req.GetCustomerId(), i)
+	}
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	return &pb.UpdateInfoResponse{Customer: gs.customer(ctx, c)}, nil
 }
 
 func (gs *grpcServer) SetState(ctx context.Context, req *pb.SetStateRequest) (*pb.SetStateResponse, error) {
-	return nil, nil
+	if err := gs.svc.SetState(ctx, req.GetCustomerId(), customer.State(req.GetState()+1)); err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	return &pb.SetStateResponse{Msg: "OK"}, nil
 }
 
-func (gs *grpcServer) mustEmbedUnimplementedCustomerRegistryServer()
+func (gs *grpcServer) Erase(ctx context.Context, req *pb.EraseRequest) (*pb.EraseResponse, error) {
+	if err := gs.svc.Erase(ctx, req.GetCustomerId()); err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	return &pb.EraseResponse{Msg: "OK"}, nil
+}
+
+func (gs *grpcServer) SubjectAccessReport(ctx context.Context, req *pb.SubjectAccessReportRequest) (*pb.SubjectAccessReportResponse, error) {
+	r, err := gs.svc.SubjectAccessReport(ctx, req.GetSsn())
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	doc, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, gs.internalError(err)
+	}
+
+	return &pb.SubjectAccessReportResponse{Json: doc, Text: r.Text()}, nil
+}
+
+func (gs *grpcServer) List(ctx context.Context, req *pb.ListRequest) (*pb.ListResponse, error) {
+	cs, err := gs.svc.List(ctx, req.GetPageToken(), int(req.GetPageSize()))
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	resp := &pb.ListResponse{Customers: gs.customers(ctx, cs)}
+
+	size := int(req.GetPageSize())
+	if size == 0 {
+		size = registry.DefaultLimit
+	}
+
+	if len(cs) == size {
+		resp.NextPageToken = cs[len(cs)-1].ID
+	}
+
+	return resp, nil
+}
+
+func (gs *grpcServer) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
+	cs, err := gs.svc.Search(ctx, req.GetQuery(), int(req.GetLimit()))
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	return &pb.SearchResponse{Customers: gs.customers(ctx, cs)}, nil
+}
+
+func (gs *grpcServer) BatchGet(ctx context.Context, req *pb.BatchGetRequest) (*pb.BatchGetResponse, error) {
+	rs, err := gs.svc.BatchGet(ctx, req.GetCustomerIds())
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	return &pb.BatchGetResponse{Results: gs.batchResults(ctx, rs)}, nil
+}
+
+func (gs *grpcServer) BatchSetState(ctx context.Context, req *pb.BatchSetStateRequest) (*pb.BatchSetStateResponse, error) {
+	updates := make([]registry.StateUpdate, 0, len(req.GetUpdates()))
+	for _, u := range req.GetUpdates() {
+		updates = append(updates, registry.StateUpdate{ID: u.GetCustomerId(), State: customer.State(u.GetState() + 1)})
+	}
+
+	rs, err := gs.svc.BatchSetState(ctx, updates)
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	return &pb.BatchSetStateResponse{Results: gs.batchResults(ctx, rs)}, nil
+}
+
+func (gs *grpcServer) GetAsOf(ctx context.Context, req *pb.GetAsOfRequest) (*pb.GetAsOfResponse, error) {
+	v, err := gs.svc.GetAsOf(ctx, req.GetCustomerId(), FromPBTime(req.GetValidTime()), FromPBTime(req.GetRecordedTime()))
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	pv := ToPBInfoVersion(v)
+	gs.redaction.RedactVersion(ctx, pv)
+
+	return &pb.GetAsOfResponse{Version: pv}, nil
+}
+
+func (gs *grpcServer) CorrectInfo(ctx context.Context, req *pb.CorrectInfoRequest) (*pb.CorrectInfoResponse, error) {
+	i, err := fromPBRequestInfo(req)
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	c, err := gs.svc.CorrectInfo(ctx, req.GetCustomerId(), i, FromPBTime(req.GetValidFrom()), FromPBTime(req.GetValidTo()))
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	return &pb.CorrectInfoResponse{Customer: gs.customer(ctx, c)}, nil
+}
+
+func (gs *grpcServer) ConvertType(ctx context.Context, req *pb.ConvertTypeRequest) (*pb.ConvertTypeResponse, error) {
+	i, err := fromPBRequestInfo(req)
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	c, err := gs.svc.ConvertType(ctx, req.GetCustomerId(), i)
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	return &pb.ConvertTypeResponse{Customer: gs.customer(ctx, c)}, nil
+}
+
+func (gs *grpcServer) Merge(ctx context.Context, req *pb.MergeRequest) (*pb.MergeResponse, error) {
+	c, err := gs.svc.Merge(ctx, req.GetSourceId(), req.GetTargetId())
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	return &pb.MergeResponse{Customer: gs.customer(ctx, c)}, nil
+}
+
+func (gs *grpcServer) Unmerge(ctx context.Context, req *pb.UnmergeRequest) (*pb.UnmergeResponse, error) {
+	c, err := gs.svc.Unmerge(ctx, req.GetCustomerId())
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	return &pb.UnmergeResponse{Customer: gs.customer(ctx, c)}, nil
+}
+
+func (gs *grpcServer) FindDuplicates(ctx context.Context, req *pb.FindDuplicatesRequest) (*pb.FindDuplicatesResponse, error) {
+	ds, err := gs.svc.FindDuplicates(ctx, req.GetMinScore(), int(req.GetLimit()))
+	if err != nil {
+		return nil, gs.grpcError(ctx, err)
+	}
+
+	resp := &pb.FindDuplicatesResponse{}
+	for _, d := range ds {
+		resp.Duplicates = append(resp.Duplicates, ToPBDuplicate(d))
+	}
+
+	return resp, nil
+}
+
+// batchResults carries per item errors as statuses mapped like errors of single item calls
+func (gs *grpcServer) batchResults(ctx context.Context, rs []registry.BatchResult) []*pb.BatchResult {
+	prs := make([]*pb.BatchResult, 0, len(rs))
+	for _, r := range rs {
+		pr := &pb.BatchResult{CustomerId: r.ID, Status: status.New(codes.OK, "").Proto()}
+		if r.Err != nil {
+			pr.Status = status.Convert(gs.grpcError(ctx, r.Err)).Proto()
+		} else {
+			pr.Customer = gs.customer(ctx, r.Customer)
+		}
+		prs = append(prs, pr)
+	}
+	return prs
+}
+
+// IdempotencyKeyHeader is the request metadata key of New idempotency keys
+const IdempotencyKeyHeader = "idempotency-key"
+
+// idempotencyKey returns the key of the request, the field takes precedence over metadata
+func idempotencyKey(ctx context.Context, req *pb.NewRequest) string {
+	if key := req.GetIdempotencyKey(); key != "" {
+		return key
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(IdempotencyKeyHeader); len(v) > 0 {
+		return v[0]
+	}
+
+	return ""
+}
+
+func (gs *grpcServer) customers(ctx context.Context, cs []*customer.Customer) []*pb.Customer {
+	pcs := make([]*pb.Customer, 0, len(cs))
+	for _, c := range cs {
+		pcs = append(pcs, gs.customer(ctx, c))
+	}
+	return pcs
+}
+
+func (gs *grpcServer) customer(ctx context.Context, c *customer.Customer) *pb.Customer {
+	pc := ToPBCustomer(c)
+	gs.redaction.Redact(ctx, pc)
+	return pc
+}
+
+// grpcError maps registry error marks to gRPC status codes, validation failures of fields are
+// described in the language of the request
+func (gs *grpcServer) grpcError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, registry.ErrPermission):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, registry.ErrConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, registry.ErrValidation):
+		return validationError(ctx, err)
+	case errors.Is(err, registry.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, registry.ErrExpected):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return gs.internalError(err)
+}
+
+// internalError logs err and returns a generic status, the error chain names operations and
+// may carry repository or key details
+func (gs *grpcServer) internalError(err error) error {
+	gs.log.Errorf("%v", err)
+	return status.Error(codes.Internal, "internal error")
+}
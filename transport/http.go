@@ -0,0 +1,728 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nacobas/customer/pb"
+	"github.com/nacobas/customer/registry"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NewHTTPHandler serves the registry as a resource style JSON API under /v1. Requests are
+// authenticated and resolved to a tenant the same way as gRPC requests, the Authorization,
+// X-Tenant-Id, X-Roles and Idempotency-Key headers are read as request metadata. X-Roles is
+// ignored unless the redaction policy trusts it.
+func NewHTTPHandler(svc registry.Service, authenticators []Authenticator, opts ...ServerOption) http.Handler {
+	gs := NewGRPCServer(svc, opts...).(*grpcServer)
+
+	return &httpHandler{gs: gs, authenticators: authenticators}
+}
+
+type httpHandler struct {
+	gs             *grpcServer
+	authenticators []Authenticator
+}
+
+type route struct {
+	method   string
+	pattern  string
+	summary  string
+	request  proto.Message
+	response proto.Message
+	// status of a successful response
+	status int
+	handle func(h *httpHandler, ctx context.Context, r *http.Request, params map[string]string) (proto.Message, error)
+}
+
+var routes = []route{
+	{
+		method: http.MethodGet, pattern: "/v1/customers",
+		summary:  "List customers ordered by ID",
+		response: &pb.ListResponse{}, status: http.StatusOK,
+		handle: (*httpHandler).list,
+	},
+	{
+		method: http.MethodPost, pattern: "/v1/customers",
+		summary: "Create a customer",
+		request: &pb.NewRequest{}, response: &pb.Customer{}, status: http.StatusCreated,
+		handle: (*httpHandler).create,
+	},
+	{
+		method: http.MethodGet, pattern: "/v1/customers:search",
+		summary:  "Search customers by name, SSN or legal ID",
+		response: &pb.SearchResponse{}, status: http.StatusOK,
+		handle: (*httpHandler).search,
+	},
+	{
+		method: http.MethodGet, pattern: "/v1/customers:duplicates",
+		summary:  "Rank probable duplicate customers",
+		response: &pb.FindDuplicatesResponse{}, status: http.StatusOK,
+		handle: (*httpHandler).findDuplicates,
+	},
+	{
+		method: http.MethodPost, pattern: "/v1/customers:batchGet",
+		summary: "Get customers by ID with a result per ID",
+		request: &pb.BatchGetRequest{}, response: &pb.BatchGetResponse{}, status: http.StatusOK,
+		handle: (*httpHandler).batchGet,
+	},
+	{
+		method: http.MethodPost, pattern: "/v1/customers:batchSetState",
+		summary: "Set the state of customers with a result per customer",
+		request: &pb.BatchSetStateRequest{}, response: &pb.BatchSetStateResponse{}, status: http.StatusOK,
+		handle: (*httpHandler).batchSetState,
+	},
+	{
+		method: http.MethodGet, pattern: "/v1/customers/{id}",
+		summary:  "Get a customer",
+		response: &pb.Customer{}, status: http.StatusOK,
+		handle: (*httpHandler).get,
+	},
+	{
+		method: http.MethodPatch, pattern: "/v1/customers/{id}",
+		summary: "Update customer info",
+		request: &pb.UpdateInfoRequest{}, response: &pb.Customer{}, status: http.StatusOK,
+		handle: (*httpHandler).updateInfo,
+	},
+	{
+		method: http.MethodGet, pattern: "/v1/customers/{id}:asOf",
+		summary:  "Get customer info valid at a time as known at a time",
+		response: &pb.InfoVersion{}, status: http.StatusOK,
+		handle: (*httpHandler).getAsOf,
+	},
+	{
+		method: http.MethodPost, pattern: "/v1/customers/{id}:correctInfo",
+		summary: "Correct customer info for a past period",
+		request: &pb.CorrectInfoRequest{}, response: &pb.Customer{}, status: http.StatusOK,
+		handle: (*httpHandler).correctInfo,
+	},
+	{
+		method: http.MethodPost, pattern: "/v1/customers/{id}:convertType",
+		summary: "Replace the info of a prospect with info of another type",
+		request: &pb.ConvertTypeRequest{}, response: &pb.Customer{}, status: http.StatusOK,
+		handle: (*httpHandler).convertType,
+	},
+	{
+		method: http.MethodPost, pattern: "/v1/customers/{id}:merge",
+		summary: "Merge the customer into the target and return the target",
+		request: &pb.MergeRequest{}, response: &pb.Customer{}, status: http.StatusOK,
+		handle: (*httpHandler).merge,
+	},
+	{
+		method: http.MethodPost, pattern: "/v1/customers/{id}:unmerge",
+		summary:  "Restore a merged customer within the merge grace period",
+		response: &pb.Customer{}, status: http.StatusOK,
+		handle: (*httpHandler).unmerge,
+	},
+	{
+		method: http.MethodPost, pattern: "/v1/customers/{id}:setState",
+		summary: "Set customer state",
+		request: &pb.SetStateRequest{}, status: http.StatusNoContent,
+		handle: (*httpHandler).setState,
+	},
+	{
+		method: http.MethodPost, pattern: "/v1/customers/{id}:erase",
+		summary: "Erase personal data of a customer",
+		status:  http.StatusNoContent,
+		handle:  (*httpHandler).erase,
+	},
+	{
+		method: http.MethodPost, pattern: "/v1/subjectAccessReports",
+		summary: "Report all data held on a data subject, text/plain on request",
+		request: &pb.SubjectAccessReportRequest{}, status: http.StatusOK,
+		handle: (*httpHandler).subjectAccessReport,
+	},
+}
+
+var (
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	unmarshalOptions = protojson.UnmarshalOptions{}
+)
+
+func (h *httpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && r.URL.Path == "/v1/openapi.json" {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(openAPIDocument)
+		return
+	}
+
+	var allowed []string
+
+	for _, rt := range routes {
+		params, ok := match(rt.pattern, r.URL.Path)
+		if !ok {
+			continue
+		}
+		if rt.method != r.Method {
+			allowed = append(allowed, rt.method)
+			continue
+		}
+
+		ctx, err := h.context(r)
+		if err != nil {
+			writeProblem(w, err)
+			return
+		}
+
+		resp, err := rt.handle(h, ctx, r, params)
+		if err != nil {
+			writeProblem(w, err)
+			return
+		}
+
+		if raw, ok := resp.(*rawResponse); ok {
+			w.Header().Set("Content-Type", raw.contentType)
+			w.WriteHeader(rt.status)
+			w.Write(raw.body)
+			return
+		}
+
+		if resp == nil || rt.status == http.StatusNoContent {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		b, err := marshalOptions.Marshal(resp)
+		if err != nil {
+			writeProblem(w, h.gs.internalError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(rt.status)
+		w.Write(b)
+		return
+	}
+
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		writeProblemDetails(w, problem{
+			Type:   "/problems/method-not-allowed",
+			Title:  http.StatusText(http.StatusMethodNotAllowed),
+			Status: http.StatusMethodNotAllowed,
+			Detail: r.Method + " is not allowed on " + r.URL.Path,
+		})
+		return
+	}
+
+	writeProblem(w, status.Errorf(codes.NotFound, "no route for %s", r.URL.Path))
+}
+
+// context carries headers as gRPC metadata and applies authentication and tenant resolution
+func (h *httpHandler) context(r *http.Request) (context.Context, error) {
+	md := metadata.MD{}
+	for header, key := range map[string]string{
+		"Authorization":   "authorization",
+		"X-Tenant-Id":     TenantHeader,
+		"X-Roles":         RolesHeader,
+		"Idempotency-Key": IdempotencyKeyHeader,
+		"Accept-Language": AcceptLanguageHeader,
+	} {
+		if v := r.Header.Values(header); len(v) > 0 {
+			md.Set(key, v...)
+		}
+	}
+
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+
+	// lets MTLSAuthenticator see the client certificate
+	if r.TLS != nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{
+			Addr:     httpAddr(r.RemoteAddr),
+			AuthInfo: credentials.TLSInfo{State: *r.TLS},
+		})
+	}
+
+	ctx, err := authenticate(ctx, h.authenticators)
+	if err != nil {
+		return ctx, err
+	}
+
+	return resolveTenant(ctx)
+}
+
+func (h *httpHandler) list(ctx context.Context, r *http.Request, params map[string]string) (proto.Message, error) {
+	size, err := queryUint(r, "page_size")
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := queryUint(r, "page_token")
+	if err != nil {
+		return nil, err
+	}
+
+	return h.gs.List(ctx, &pb.ListRequest{PageSize: size, PageToken: token})
+}
+
+func (h *httpHandler) create(ctx context.Context, r *http.Request, params map[string]string) (proto.Message, error) {
+	req := &pb.NewRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	resp, err := h.gs.New(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetCustomer(), nil
+}
+
+func (h *httpHandler) search(ctx context.Context, r *http.Request, params map[string]string) (proto.Message, error) {
+	limit, err := queryUint(r, "limit")
+	if err != nil {
+		return nil, err
+	}
+
+	return h.gs.Search(ctx, &pb.SearchRequest{Query: r.URL.Query().Get("q"), Limit: limit})
+}
+
+func (h *httpHandler) findDuplicates(ctx context.Context, r *http.Request, params map[string]string) (proto.Message, error) {
+	limit, err := queryUint(r, "limit")
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pb.FindDuplicatesRequest{Limit: limit}
+
+	if v := r.URL.Query().Get("min_score"); v != "" {
+		if req.MinScore, err = strconv.ParseFloat(v, 64); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid min_score %q", v)
+		}
+	}
+
+	return h.gs.FindDuplicates(ctx, req)
+}
+
+func (h *httpHandler) batchGet(ctx context.Context, r *http.Request, params map[string]string) (proto.Message, error) {
+	req := &pb.BatchGetRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	return h.gs.BatchGet(ctx, req)
+}
+
+func (h *httpHandler) batchSetState(ctx context.Context, r *http.Request, params map[string]string) (proto.Message, error) {
+	req := &pb.BatchSetStateRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	return h.gs.BatchSetState(ctx, req)
+}
+
+func (h *httpHandler) get(ctx context.Context, r *http.Request, params map[string]string) (proto.Message, error) {
+	id, err := pathID(params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.gs.Get(ctx, &pb.GetRequest{CustomerId: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetCustomer(), nil
+}
+
+func (h *httpHandler) updateInfo(ctx context.Context, r *http.Request, params map[string]string) (proto.Message, error) {
+	id, err := pathID(params)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pb.UpdateInfoRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+	req.CustomerId = id
+
+	resp, err := h.gs.UpdateInfo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetCustomer(), nil
+}
+
+func (h *httpHandler) getAsOf(ctx context.Context, r *http.Request, params map[string]string) (proto.Message, error) {
+	id, err := pathID(params)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pb.GetAsOfRequest{CustomerId: id}
+
+	if req.ValidTime, err = queryTime(r, "valid_time"); err != nil {
+		return nil, err
+	}
+
+	if req.RecordedTime, err = queryTime(r, "recorded_time"); err != nil {
+		return nil, err
+	}
+
+	resp, err := h.gs.GetAsOf(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetVersion(), nil
+}
+
+func (h *httpHandler) correctInfo(ctx context.Context, r *http.Request, params map[string]string) (proto.Message, error) {
+	id, err := pathID(params)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pb.CorrectInfoRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+	req.CustomerId = id
+
+	resp, err := h.gs.CorrectInfo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetCustomer(), nil
+}
+
+func (h *httpHandler) convertType(ctx context.Context, r *http.Request, params map[string]string) (proto.Message, error) {
+	id, err := pathID(params)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pb.ConvertTypeRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+	req.CustomerId = id
+
+	resp, err := h.gs.ConvertType(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetCustomer(), nil
+}
+
+func (h *httpHandler) merge(ctx context.Context, r *http.Request, params map[string]string) (proto.Message, error) {
+	id, err := pathID(params)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pb.MergeRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+	req.SourceId = id
+
+	resp, err := h.gs.Merge(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetCustomer(), nil
+}
+
+func (h *httpHandler) unmerge(ctx context.Context, r *http.Request, params map[string]string) (proto.Message, error) {
+	id, err := pathID(params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.gs.Unmerge(ctx, &pb.UnmergeRequest{CustomerId: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetCustomer(), nil
+}
+
+func (h *httpHandler) setState(ctx context.Context, r *http.Request, params map[string]string) (proto.Message, error) {
+	id, err := pathID(params)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pb.SetStateRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+	req.CustomerId = id
+
+	_, err = h.gs.SetState(ctx, req)
+
+	return nil, err
+}
+
+func (h *httpHandler) erase(ctx context.Context, r *http.Request, params map[string]string) (proto.Message, error) {
+	id, err := pathID(params)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = h.gs.Erase(ctx, &pb.EraseRequest{CustomerId: id})
+
+	return nil, err
+}
+
+func (h *httpHandler) subjectAccessReport(ctx context.Context, r *http.Request, params map[string]string) (proto.Message, error) {
+	req := &pb.SubjectAccessReportRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	resp, err := h.gs.SubjectAccessReport(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), "text/plain") {
+		return &rawResponse{contentType: "text/plain; charset=utf-8", body: []byte(resp.GetText())}, nil
+	}
+
+	return &rawResponse{contentType: "application/json", body: resp.GetJson()}, nil
+}
+
+// rawResponse is written as is instead of being marshalled
+type rawResponse struct {
+	proto.Message
+	contentType string
+	body        []byte
+}
+
+func decode(r *http.Request, m proto.Message) error {
+	b, err := ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, 1<<20))
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := unmarshalOptions.Unmarshal(camelMasks(b, m.ProtoReflect().Descriptor()), m); err != nil {
+		return status.Errorf(codes.InvalidArgument, "malformed request body: %v", err)
+	}
+
+	return nil
+}
+
+// camelMasks rewrites snake_case paths of top level field masks in b to the lowerCamelCase
+// protojson expects, so that masks name fields the same way the rest of the body does
+func camelMasks(b []byte, md protoreflect.MessageDescriptor) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return b
+	}
+
+	var changed bool
+	for name, raw := range fields {
+		fd := md.Fields().ByJSONName(name)
+		if fd == nil {
+			fd = md.Fields().ByName(protoreflect.Name(name))
+		}
+		if fd == nil || fd.Message() == nil || fd.Message().FullName() != "google.protobuf.FieldMask" {
+			continue
+		}
+
+		var mask string
+		if err := json.Unmarshal(raw, &mask); err != nil {
+			continue
+		}
+
+		paths := strings.Split(mask, ",")
+		for i, p := range paths {
+			paths[i] = lowerCamel(p)
+		}
+		rewritten, err := json.Marshal(strings.Join(paths, ","))
+		if err != nil {
+			return b
+		}
+		fields[name] = rewritten
+		changed = true
+	}
+
+	if !changed {
+		return b
+	}
+
+	out, err := json.Marshal(fields)
+	if err != nil {
+		return b
+	}
+
+	return out
+}
+
+func lowerCamel(path string) string {
+	var sb strings.Builder
+	upper := false
+	for _, r := range path {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			sb.WriteString(strings.ToUpper(string(r)))
+			upper = false
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
+
+func pathID(params map[string]string) (uint32, error) {
+	id, err := strconv.ParseUint(params["id"], 10, 32)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid customer ID %q", params["id"])
+	}
+	return uint32(id), nil
+}
+
+func queryUint(r *http.Request, key string) (uint32, error) {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseUint(v, 10, 32)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid %s %q", key, v)
+	}
+	return uint32(n), nil
+}
+
+// queryTime parses an RFC 3339 time, or a date for midnight UTC, absent is unset
+func queryTime(r *http.Request, key string) (*timestamppb.Timestamp, error) {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		if t, err = time.Parse("2006-01-02", v); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid %s %q", key, v)
+		}
+	}
+	return timestamppb.New(t), nil
+}
+
+// match matches path against a pattern where {name} matches a single path segment, a
+// custom method suffix such as :setState must match exactly
+func match(pattern, path string) (map[string]string, bool) {
+	ps := strings.Split(strings.Trim(pattern, "/"), "/")
+	ss := strings.Split(strings.Trim(path, "/"), "/")
+
+	if len(ps) != len(ss) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+
+	for i, p := range ps {
+		if !strings.HasPrefix(p, "{") {
+			if p != ss[i] {
+				return nil, false
+			}
+			continue
+		}
+
+		end := strings.Index(p, "}")
+		name, verb := p[1:end], p[end+1:]
+
+		if !strings.HasSuffix(ss[i], verb) || len(ss[i]) == len(verb) {
+			return nil, false
+		}
+
+		v := strings.TrimSuffix(ss[i], verb)
+		if verb == "" && strings.Contains(v, ":") {
+			return nil, false
+		}
+		params[name] = v
+	}
+
+	return params, true
+}
+
+type httpAddr string
+
+func (a httpAddr) Network() string { return "tcp" }
+func (a httpAddr) String() string  { return string(a) }
+
+// problem is an RFC 7807 problem details object
+type problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// InvalidParams are the fields that failed validation
+	InvalidParams []invalidParam `json:"invalid_params,omitempty"`
+}
+
+type invalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+var problems = map[codes.Code]struct {
+	status int
+	slug   string
+}{
+	codes.InvalidArgument:    {http.StatusBadRequest, "validation-failed"},
+	codes.Unauthenticated:    {http.StatusUnauthorized, "unauthenticated"},
+	codes.PermissionDenied:   {http.StatusForbidden, "permission-denied"},
+	codes.NotFound:           {http.StatusNotFound, "not-found"},
+	codes.Unimplemented:      {http.StatusNotImplemented, "not-implemented"},
+	codes.AlreadyExists:      {http.StatusConflict, "conflict"},
+	codes.FailedPrecondition: {http.StatusUnprocessableEntity, "precondition-failed"},
+	codes.Internal:           {http.StatusInternalServerError, "unexpected"},
+}
+
+// writeProblem maps the gRPC status of err, itself mapped from registry error marks, to
+// an application/problem+json response
+func writeProblem(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+
+	p, ok := problems[st.Code()]
+	if !ok {
+		p = problems[codes.Internal]
+	}
+
+	pd := problem{
+		Type:   "/problems/" + p.slug,
+		Title:  http.StatusText(p.status),
+		Status: p.status,
+		Detail: st.Message(),
+	}
+	for _, fv := range fieldViolations(st) {
+		pd.InvalidParams = append(pd.InvalidParams, invalidParam{Name: fv.GetField(), Reason: fv.GetDescription()})
+	}
+
+	writeProblemDetails(w, pd)
+}
+
+func writeProblemDetails(w http.ResponseWriter, pd problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(pd.Status)
+
+	json.NewEncoder(w).Encode(pd)
+}
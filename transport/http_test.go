@@ -0,0 +1,346 @@
+package transport_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/registry"
+	"github.com/nacobas/customer/repo/inmem"
+	"github.com/nacobas/customer/transport"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	t.Parallel()
+
+	svc := registry.NewService(inmem.NewRepoWithSeed(seed(t)), registry.WithInfoHistory(inmem.NewInfoHistory()))
+	srv := httptest.NewServer(transport.NewHTTPHandler(svc, nil))
+	defer srv.Close()
+
+	testCases := []struct {
+		desc        string
+		method      string
+		path        string
+		body        string
+		status      int
+		contentType string
+		contains    string
+		allow       string
+	}{
+		{
+			desc:   "get",
+			method: http.MethodGet, path: "/v1/customers/1",
+			status: http.StatusOK, contentType: "application/json",
+			contains: `"ssn":"010170-123A"`,
+		},
+		{
+			desc:   "get unknown customer",
+			method: http.MethodGet, path: "/v1/customers/99",
+			status: http.StatusNotFound, contentType: "application/problem+json",
+			contains: `"type":"/problems/not-found"`,
+		},
+		{
+			desc:   "malformed ID",
+			method: http.MethodGet, path: "/v1/customers/abc",
+			status: http.StatusBadRequest, contentType: "application/problem+json",
+		},
+		{
+			desc:   "create",
+			method: http.MethodPost, path: "/v1/customers",
+			body:   `{"person_info": {"given_name": "new", "family_name": "person", "ssn": "020270-456B", "date_of_birth": "1970-02-02", "citizenship": "SE"}}`,
+			status: http.StatusCreated, contentType: "application/json",
+			contains: `"given_name":"new"`,
+		},
+		{
+			desc:   "create with typed date",
+			method: http.MethodPost, path: "/v1/customers",
+			body:   `{"person_info": {"given_name": "typed", "family_name": "person", "ssn": "030370-789C", "birth_date": {"year": 1970, "month": 3, "day": 3}, "citizenship": "SE"}}`,
+			status: http.StatusCreated, contentType: "application/json",
+			contains: `"birth_date":{"year":1970,"month":3,"day":3}`,
+		},
+		{
+			desc:   "create malformed date",
+			method: http.MethodPost, path: "/v1/customers",
+			body:   `{"person_info": {"given_name": "new", "family_name": "person", "ssn": "040470-012D", "date_of_birth": "1970-4-4", "citizenship": "SE"}}`,
+			status: http.StatusBadRequest, contentType: "application/problem+json",
+			contains: `wantYYYY-MM-DD`,
+		},
+		{
+			desc:   "create non-existent typed date",
+			method: http.MethodPost, path: "/v1/customers",
+			body:   `{"person_info": {"given_name": "new", "family_name": "person", "ssn": "040470-012D", "birth_date": {"year": 1970, "month": 2, "day": 30}, "citizenship": "SE"}}`,
+			status: http.StatusBadRequest, contentType: "application/problem+json",
+			contains: `"type":"/problems/validation-failed"`,
+		},
+		{
+			desc:   "create invalid",
+			method: http.MethodPost, path: "/v1/customers",
+			body:   `{"person_info": {"given_name": "new"}}`,
+			status: http.StatusBadRequest, contentType: "application/problem+json",
+			contains: `"type":"/problems/validation-failed"`,
+		},
+		{
+			desc:   "create duplicate SSN",
+			method: http.MethodPost, path: "/v1/customers",
+			body:   `{"person_info": {"given_name": "new", "family_name": "person", "ssn": "010170-123A", "date_of_birth": "1970-02-02", "citizenship": "SE"}}`,
+			status: http.StatusConflict, contentType: "application/problem+json",
+		},
+		{
+			desc:   "malformed body",
+			method: http.MethodPost, path: "/v1/customers",
+			body:   `{`,
+			status: http.StatusBadRequest, contentType: "application/problem+json",
+		},
+		{
+			desc:   "patch info",
+			method: http.MethodPatch, path: "/v1/customers/2",
+			body:   `{"organization_info": {"name": "new-name", "form": "Ltd", "legal_id": "legal-id", "date_of_registration": "1970-01-01", "registration_country": "FI"}}`,
+			status: http.StatusOK, contentType: "application/json",
+			contains: `"name":"new-name"`,
+		},
+		{
+			desc:   "patch masked fields",
+			method: http.MethodPatch, path: "/v1/customers/2",
+			body:   `{"organization_info": {"form": "Oy", "name": "ignored"}, "update_mask": "form"}`,
+			status: http.StatusOK, contentType: "application/json",
+			contains: `"name":"new-name","form":"Oy"`,
+		},
+		{
+			desc:   "patch snake case mask",
+			method: http.MethodPatch, path: "/v1/customers/2",
+			body:   `{"organization_info": {"registration_country": "SE", "name": "ignored"}, "update_mask": "registration_country"}`,
+			status: http.StatusOK, contentType: "application/json",
+			contains: `"registration_country":"SE"`,
+		},
+		{
+			desc:   "patch unknown field",
+			method: http.MethodPatch, path: "/v1/customers/2",
+			body:   `{"organization_info": {}, "update_mask": "phone"}`,
+			status: http.StatusBadRequest, contentType: "application/problem+json",
+		},
+		{
+			desc:   "as of",
+			method: http.MethodGet, path: "/v1/customers/2:asOf?valid_time=2019-06-30",
+			status: http.StatusOK, contentType: "application/json",
+			contains: `"name":"org-name"`,
+		},
+		{
+			desc:   "as of invalid time",
+			method: http.MethodGet, path: "/v1/customers/2:asOf?valid_time=june",
+			status: http.StatusBadRequest, contentType: "application/problem+json",
+		},
+		{
+			desc:   "correct info",
+			method: http.MethodPost, path: "/v1/customers/2:correctInfo",
+			body:   `{"organization_info": {"name": "old-name", "form": "Ltd", "legal_id": "legal-id", "date_of_registration": "1970-01-01", "registration_country": "FI"}, "valid_from": "2019-01-01T00:00:00Z", "valid_to": "2020-01-01T00:00:00Z"}`,
+			status: http.StatusOK, contentType: "application/json",
+			contains: `"name":"new-name"`,
+		},
+		{
+			desc:   "as of corrected",
+			method: http.MethodGet, path: "/v1/customers/2:asOf?valid_time=2019-06-30T00:00:00Z",
+			status: http.StatusOK, contentType: "application/json",
+			contains: `"name":"old-name"`,
+		},
+		{
+			desc:   "merge type mismatch",
+			method: http.MethodPost, path: "/v1/customers/2:merge",
+			body:   `{"target_id": 1}`,
+			status: http.StatusUnprocessableEntity, contentType: "application/problem+json",
+			contains: `"type":"/problems/precondition-failed"`,
+		},
+		{
+			desc:   "set state",
+			method: http.MethodPost, path: "/v1/customers/1:setState",
+			body:   `{"state": "PASSIVE"}`,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "batch get",
+			method: http.MethodPost, path: "/v1/customers:batchGet",
+			body:   `{"customer_ids": [1, 99]}`,
+			status: http.StatusOK, contentType: "application/json",
+			contains: `"code":5`,
+		},
+		{
+			desc:   "list",
+			method: http.MethodGet, path: "/v1/customers?page_size=1",
+			status: http.StatusOK, contentType: "application/json",
+			contains: `"next_page_token":1`,
+		},
+		{
+			desc:   "search",
+			method: http.MethodGet, path: "/v1/customers:search?q=new-name",
+			status: http.StatusOK, contentType: "application/json",
+			contains: `"id":2`,
+		},
+		{
+			desc:   "method not allowed",
+			method: http.MethodDelete, path: "/v1/customers/1",
+			status: http.StatusMethodNotAllowed, contentType: "application/problem+json",
+			allow: "GET, PATCH",
+		},
+		{
+			desc:   "unknown route",
+			method: http.MethodGet, path: "/v1/accounts",
+			status: http.StatusNotFound, contentType: "application/problem+json",
+		},
+		{
+			desc:   "openapi document",
+			method: http.MethodGet, path: "/v1/openapi.json",
+			status: http.StatusOK, contentType: "application/json",
+			contains: `"/v1/customers/{id}:setState"`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			req, err := http.NewRequest(tC.method, srv.URL+tC.path, strings.NewReader(tC.body))
+			if err != nil {
+				t.Fatalf("Failed to build request: %v", err)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			b, err := ioutil.ReadAll(resp.Body)
+			assert.Nil(t, err, "error should be nil")
+
+			assert.Equal(t, tC.status, resp.StatusCode, string(b))
+			assert.Equal(t, tC.contentType, resp.Header.Get("Content-Type"), "content type")
+			assert.Contains(t, strings.ReplaceAll(string(b), " ", ""), tC.contains, "body")
+			assert.Equal(t, tC.allow, resp.Header.Get("Allow"), "allowed methods")
+		})
+	}
+}
+
+func TestLocalisedValidation(t *testing.T) {
+	t.Parallel()
+
+	svc := registry.NewService(inmem.NewRepoWithSeed(seed(t)))
+	srv := httptest.NewServer(transport.NewHTTPHandler(svc, nil))
+	defer srv.Close()
+
+	testCases := []struct {
+		desc           string
+		acceptLanguage string
+		detail         string
+		reason         string
+	}{
+		{desc: "default", detail: "Input validation failed", reason: "is required"},
+		{desc: "finnish", acceptLanguage: "fi-FI, en;q=0.5", detail: "Syötteen tarkistus epäonnistui", reason: "on pakollinen"},
+		{desc: "swedish", acceptLanguage: "sv", detail: "Validering av indata misslyckades", reason: "är obligatoriskt"},
+		{desc: "unsupported", acceptLanguage: "de", detail: "Input validation failed", reason: "is required"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, srv.URL+"/v1/customers",
+				strings.NewReader(`{"person_info": {"given_name": "new", "family_name": "person", "date_of_birth": "1970-02-02", "citizenship": "SE"}}`))
+			if err != nil {
+				t.Fatalf("Failed to build request: %v", err)
+			}
+			if tC.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tC.acceptLanguage)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			var p struct {
+				Detail        string `json:"detail"`
+				InvalidParams []struct {
+					Name   string `json:"name"`
+					Reason string `json:"reason"`
+				} `json:"invalid_params"`
+			}
+			assert.Nil(t, json.NewDecoder(resp.Body).Decode(&p), "problem should be JSON")
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "status")
+			assert.Contains(t, p.Detail, tC.detail, "localised detail")
+			if assert.Len(t, p.InvalidParams, 1, "one invalid field") {
+				assert.Equal(t, "ssn", p.InvalidParams[0].Name, "fields are named by JSON name")
+				assert.Equal(t, tC.reason, p.InvalidParams[0].Reason, "localised reason")
+			}
+		})
+	}
+}
+
+// failingService fails reads with an error that is not marked as a registry error
+type failingService struct {
+	registry.Service
+}
+
+func (failingService) Get(ctx context.Context, id uint32) (*customer.Customer, error) {
+	return nil, errors.Wrap(errors.New("key kid-1 not found"), "encrypted.Repo.Get")
+}
+
+type recordingLogger struct {
+	logged []string
+}
+
+func (l *recordingLogger) Errorf(format string, args ...interface{}) {
+	l.logged = append(l.logged, fmt.Sprintf(format, args...))
+}
+
+func TestInternalErrorHidden(t *testing.T) {
+	t.Parallel()
+
+	l := &recordingLogger{}
+	srv := httptest.NewServer(transport.NewHTTPHandler(failingService{}, nil, transport.WithErrorLog(l)))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/v1/customers/1")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	assert.Nil(t, err, "error should be nil")
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode, "status")
+	assert.NotContains(t, string(b), "kid-1", "internal details should not be returned")
+	assert.NotContains(t, string(b), "encrypted.Repo.Get", "operations should not be returned")
+	if assert.Len(t, l.logged, 1, "error should be logged") {
+		assert.Contains(t, l.logged[0], "encrypted.Repo.Get: key kid-1 not found", "logged error")
+	}
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	t.Parallel()
+
+	var doc struct {
+		OpenAPI    string                            `json:"openapi"`
+		Paths      map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]struct {
+					Deprecated bool `json:"deprecated"`
+				} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	assert.Nil(t, json.Unmarshal(transport.OpenAPIDocument(), &doc), "document should be JSON")
+
+	assert.Equal(t, "3.0.3", doc.OpenAPI, "version")
+	assert.Contains(t, doc.Paths["/v1/customers/{id}"], "patch", "info is updated with PATCH")
+	assert.Contains(t, doc.Paths["/v1/customers"], "post", "customers are created with POST")
+
+	person := doc.Components.Schemas["PersonInfo"].Properties
+	assert.True(t, person["date_of_birth"].Deprecated, "string date should be deprecated")
+	assert.False(t, person["birth_date"].Deprecated, "typed date should not be deprecated")
+	assert.Contains(t, doc.Components.Schemas, "Date", "typed date schema")
+}
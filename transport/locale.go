@@ -0,0 +1,62 @@
+package transport
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nacobas/customer/customer"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AcceptLanguageHeader is the request metadata key selecting the language of validation
+// messages, English, Finnish and Swedish are available
+const AcceptLanguageHeader = "accept-language"
+
+// requestLanguage returns the language of the request, English when none matches
+func requestLanguage(ctx context.Context) language.Tag {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return customer.MatchLanguage(strings.Join(md.Get(AcceptLanguageHeader), ","))
+}
+
+// validationError returns an InvalidArgument status. Validation failures of fields are
+// described in the language of the request and carried as BadRequest field violations.
+func validationError(ctx context.Context, err error) error {
+	lang := requestLanguage(ctx)
+
+	fes := customer.FieldErrors(err, lang)
+	if len(fes) == 0 {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	br := &errdetails.BadRequest{}
+	msgs := make([]string, 0, len(fes))
+	for _, fe := range fes {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fe.Field,
+			Description: fe.Message,
+		})
+		msgs = append(msgs, strings.TrimPrefix(fe.Field+": "+fe.Message, ": "))
+	}
+
+	st := status.New(codes.InvalidArgument, customer.ValidationFailed(lang)+": "+strings.Join(msgs, "; "))
+	if withDetails, err := st.WithDetails(br); err == nil {
+		st = withDetails
+	}
+
+	return st.Err()
+}
+
+// fieldViolations returns the field violations carried by status st
+func fieldViolations(st *status.Status) []*errdetails.BadRequest_FieldViolation {
+	var fvs []*errdetails.BadRequest_FieldViolation
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			fvs = append(fvs, br.GetFieldViolations()...)
+		}
+	}
+	return fvs
+}
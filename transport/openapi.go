@@ -0,0 +1,252 @@
+package transport
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// openAPIDocument describes the HTTP API, it is generated from the route table and the
+// protobuf descriptors of the request and response messages so it cannot drift from them
+var openAPIDocument = mustGenerateOpenAPI()
+
+// OpenAPIDocument returns the OpenAPI 3 document of the HTTP API served by NewHTTPHandler
+func OpenAPIDocument() []byte {
+	return openAPIDocument
+}
+
+type openAPIGen struct {
+	schemas map[string]interface{}
+}
+
+func mustGenerateOpenAPI() []byte {
+	g := &openAPIGen{schemas: map[string]interface{}{}}
+
+	paths := map[string]map[string]interface{}{}
+
+	for _, rt := range routes {
+		if paths[rt.pattern] == nil {
+			paths[rt.pattern] = map[string]interface{}{}
+		}
+		paths[rt.pattern][strings.ToLower(rt.method)] = g.operation(rt)
+	}
+
+	g.schemas["Problem"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"type":   map[string]string{"type": "string"},
+			"title":  map[string]string{"type": "string"},
+			"status": map[string]string{"type": "integer"},
+			"detail": map[string]string{"type": "string"},
+			"invalid_params": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"name":   map[string]string{"type": "string"},
+						"reason": map[string]string{"type": "string"},
+					},
+				},
+			},
+		},
+	}
+
+	b, err := json.MarshalIndent(map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]string{
+			"title":   "Customer registry",
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": g.schemas,
+			"securitySchemes": map[string]interface{}{
+				"bearer": map[string]string{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+		"security": []map[string][]string{{"bearer": {}}},
+	}, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (g *openAPIGen) operation(rt route) map[string]interface{} {
+	op := map[string]interface{}{
+		"summary": rt.summary,
+		"parameters": []interface{}{
+			map[string]interface{}{
+				"name": "X-Tenant-Id", "in": "header",
+				"schema": map[string]string{"type": "string"},
+			},
+			map[string]interface{}{
+				"name": "Accept-Language", "in": "header",
+				"description": "language of validation messages: en, fi or sv",
+				"schema":      map[string]string{"type": "string"},
+			},
+		},
+	}
+
+	params := op["parameters"].([]interface{})
+
+	if strings.Contains(rt.pattern, "{id}") {
+		params = append(params, map[string]interface{}{
+			"name": "id", "in": "path", "required": true,
+			"schema": map[string]string{"type": "integer", "format": "uint32"},
+		})
+	}
+
+	switch rt.pattern {
+	case "/v1/customers":
+		if rt.method == http.MethodGet {
+			params = append(params, queryParam("page_size"), queryParam("page_token"))
+		} else {
+			params = append(params, map[string]interface{}{
+				"name": "Idempotency-Key", "in": "header",
+				"schema": map[string]string{"type": "string"},
+			})
+		}
+	case "/v1/customers/{id}:asOf":
+		for _, name := range []string{"valid_time", "recorded_time"} {
+			params = append(params, map[string]interface{}{
+				"name": name, "in": "query",
+				"schema": map[string]string{"type": "string", "format": "date-time"},
+			})
+		}
+	case "/v1/customers:duplicates":
+		params = append(params, map[string]interface{}{
+			"name": "min_score", "in": "query",
+			"schema": map[string]string{"type": "number", "format": "double"},
+		}, queryParam("limit"))
+	case "/v1/customers:search":
+		params = append(params, map[string]interface{}{
+			"name": "q", "in": "query", "required": true,
+			"schema": map[string]string{"type": "string"},
+		}, queryParam("limit"))
+	}
+
+	op["parameters"] = params
+
+	if rt.request != nil {
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": g.ref(rt.request.ProtoReflect().Descriptor())},
+			},
+		}
+	}
+
+	success := map[string]interface{}{"description": http.StatusText(rt.status)}
+	if rt.response != nil {
+		success["content"] = map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": g.ref(rt.response.ProtoReflect().Descriptor())},
+		}
+	}
+
+	problem := map[string]interface{}{
+		"description": "Problem details",
+		"content": map[string]interface{}{
+			"application/problem+json": map[string]interface{}{"schema": map[string]string{"$ref": "#/components/schemas/Problem"}},
+		},
+	}
+
+	op["responses"] = map[string]interface{}{
+		statusKey(rt.status): success,
+		"default":            problem,
+	}
+
+	return op
+}
+
+func queryParam(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"name": name, "in": "query",
+		"schema": map[string]string{"type": "integer", "format": "uint32"},
+	}
+}
+
+func statusKey(code int) string {
+	return strconv.Itoa(code)
+}
+
+// ref registers the schema of md and returns a reference to it
+func (g *openAPIGen) ref(md protoreflect.MessageDescriptor) map[string]string {
+	name := string(md.Name())
+
+	if _, ok := g.schemas[name]; !ok {
+		// placeholder to stop recursion
+		g.schemas[name] = nil
+		g.schemas[name] = g.message(md)
+	}
+
+	return map[string]string{"$ref": "#/components/schemas/" + name}
+}
+
+func (g *openAPIGen) message(md protoreflect.MessageDescriptor) map[string]interface{} {
+	props := map[string]interface{}{}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+
+		s := g.field(fd)
+		if fd.IsList() {
+			s = map[string]interface{}{"type": "array", "items": s}
+		}
+		if opts, ok := fd.Options().(*descriptorpb.FieldOptions); ok && opts.GetDeprecated() {
+			s = map[string]interface{}{"allOf": []interface{}{s}, "deprecated": true}
+		}
+
+		props[string(fd.Name())] = s
+	}
+
+	return map[string]interface{}{"type": "object", "properties": props}
+}
+
+func (g *openAPIGen) field(fd protoreflect.FieldDescriptor) interface{} {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]string{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]string{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]string{"type": "integer", "format": "uint32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// protojson encodes 64 bit integers as strings
+		return map[string]string{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return map[string]string{"type": "number"}
+	case protoreflect.BytesKind:
+		return map[string]string{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		names := make([]string, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			names = append(names, string(values.Get(i).Name()))
+		}
+		return map[string]interface{}{"type": "string", "enum": names}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		// field masks are comma separated paths and timestamps RFC 3339 strings
+		switch fd.Message().FullName() {
+		case "google.protobuf.FieldMask":
+			return map[string]string{
+				"type":        "string",
+				"format":      "field-mask",
+				"description": "Comma separated field paths, snake_case as in the rest of the body or lowerCamelCase",
+				"example":     "family_name,citizenship",
+			}
+		case "google.protobuf.Timestamp":
+			return map[string]string{"type": "string", "format": "date-time"}
+		}
+		return g.ref(fd.Message())
+	}
+
+	return map[string]string{"type": "string"}
+}
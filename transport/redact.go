@@ -0,0 +1,242 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/auth"
+	"github.com/nacobas/customer/pb"
+	gdate "google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/grpc/metadata"
+)
+
+var (
+	ErrInvalidPolicy = errors.New("Invalid redaction policy")
+)
+
+// RolesHeader is the default request metadata key holding caller roles, read only when a
+// policy trusts it
+const RolesHeader = "x-roles"
+
+// Rule tells how a field is shown to a caller
+type Rule string
+
+const (
+	Show  Rule = "show"
+	Last4 Rule = "last4"
+	Hide  Rule = "hide"
+)
+
+// strictness orders rules, a caller with several roles gets the least strict rule
+var strictness = map[Rule]int{Show: 0, Last4: 1, Hide: 2}
+
+// FieldRules holds a rule per redactable field, missing fields are shown
+type FieldRules struct {
+	SSN         Rule `json:"ssn"`
+	DateOfBirth Rule `json:"date_of_birth"`
+	LegalID     Rule `json:"legal_id"`
+}
+
+// RedactionPolicy masks sensitive fields of pb.Customer by caller role. It is declared in a
+// JSON file:
+//
+//	{
+//	  "default": {"ssn": "hide", "date_of_birth": "hide", "legal_id": "hide"},
+//	  "roles": {
+//	    "call-centre": {"ssn": "last4", "date_of_birth": "hide", "legal_id": "show"},
+//	    "kyc-officer": {}
+//	  }
+//	}
+//
+// Roles are those of the authenticated principal. Callers without any known role get the
+// default rules.
+type RedactionPolicy struct {
+	// TrustRolesHeader reads the roles of unauthenticated requests from RolesHeader. Enable
+	// it only behind a proxy that authenticates callers and sets the header itself, any
+	// client can send it.
+	TrustRolesHeader bool                  `json:"trust_roles_header"`
+	RolesHeader      string                `json:"roles_header"`
+	Default          FieldRules            `json:"default"`
+	Roles            map[string]FieldRules `json:"roles"`
+}
+
+func LoadRedactionPolicy(path string) (*RedactionPolicy, error) {
+	const op string = "transport.LoadRedactionPolicy"
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	p, err := ParseRedactionPolicy(b)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s: %s", op, path)
+	}
+
+	return p, nil
+}
+
+func ParseRedactionPolicy(b []byte) (*RedactionPolicy, error) {
+	const op string = "transport.ParseRedactionPolicy"
+
+	p := &RedactionPolicy{}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(p); err != nil {
+		return nil, errors.Mark(errors.Wrap(err, op), ErrInvalidPolicy)
+	}
+
+	if p.RolesHeader == "" {
+		p.RolesHeader = RolesHeader
+	}
+
+	for role, fr := range p.Roles {
+		if err := fr.validate(); err != nil {
+			return nil, errors.Wrapf(err, "%s: role %s", op, role)
+		}
+	}
+
+	if err := p.Default.validate(); err != nil {
+		return nil, errors.Wrapf(err, "%s: default", op)
+	}
+
+	return p, nil
+}
+
+func (fr FieldRules) validate() error {
+	for _, r := range []Rule{fr.SSN, fr.DateOfBirth, fr.LegalID} {
+		if _, ok := strictness[r]; !ok && r != "" {
+			return errors.Wrapf(ErrInvalidPolicy, "unknown rule %q", r)
+		}
+	}
+	return nil
+}
+
+// rules returns the least strict rules of the callers roles
+func (p *RedactionPolicy) rules(ctx context.Context) FieldRules {
+	var (
+		fr    FieldRules
+		known bool
+	)
+
+	for _, role := range p.roles(ctx) {
+		r, ok := p.Roles[role]
+		if !ok {
+			continue
+		}
+		if !known {
+			fr, known = r, true
+			continue
+		}
+		fr.SSN = leastStrict(fr.SSN, r.SSN)
+		fr.DateOfBirth = leastStrict(fr.DateOfBirth, r.DateOfBirth)
+		fr.LegalID = leastStrict(fr.LegalID, r.LegalID)
+	}
+
+	if !known {
+		return p.Default
+	}
+
+	return fr
+}
+
+// Redact masks c in place according to the caller roles in ctx
+func (p *RedactionPolicy) Redact(ctx context.Context, c *pb.Customer) {
+	if p == nil || c == nil {
+		return
+	}
+
+	p.rules(ctx).redact(c.GetPersonInfo(), c.GetOrganizationInfo(), c.GetSoleTraderInfo())
+}
+
+// RedactVersion masks the info of v in place like Redact
+func (p *RedactionPolicy) RedactVersion(ctx context.Context, v *pb.InfoVersion) {
+	if p == nil || v == nil {
+		return
+	}
+
+	p.rules(ctx).redact(v.GetPersonInfo(), v.GetOrganizationInfo(), v.GetSoleTraderInfo())
+}
+
+// redact applies the SSN and date of birth rules to the person of a sole trader and the
+// legal ID rule to its business ID
+func (fr FieldRules) redact(pi *pb.PersonInfo, oi *pb.OrganizationInfo, si *pb.SoleTraderInfo) {
+	if pi != nil {
+		pi.Ssn = fr.SSN.apply(pi.Ssn)
+		pi.DateOfBirth = fr.DateOfBirth.apply(pi.DateOfBirth)
+		pi.BirthDate = fr.DateOfBirth.applyDate(pi.BirthDate)
+	}
+
+	if oi != nil {
+		oi.LegalId = fr.LegalID.apply(oi.LegalId)
+	}
+
+	if si != nil {
+		si.Ssn = fr.SSN.apply(si.Ssn)
+		si.DateOfBirth = fr.DateOfBirth.apply(si.DateOfBirth)
+		si.BirthDate = fr.DateOfBirth.applyDate(si.BirthDate)
+		si.BusinessId = fr.LegalID.apply(si.BusinessId)
+	}
+}
+
+func (r Rule) apply(v string) string {
+	switch r {
+	case Hide:
+		return ""
+	case Last4:
+		rs := []rune(v)
+		if len(rs) <= 4 {
+			return strings.Repeat("*", len(rs))
+		}
+		return strings.Repeat("*", len(rs)-4) + string(rs[len(rs)-4:])
+	}
+	return v
+}
+
+// applyDate shows or hides a date, a date can not be partially masked so last4 hides it
+func (r Rule) applyDate(d *gdate.Date) *gdate.Date {
+	if r == Show || r == "" {
+		return d
+	}
+	return nil
+}
+
+func leastStrict(a, b Rule) Rule {
+	if strictness[b] < strictness[a] {
+		return b
+	}
+	return a
+}
+
+// roles returns the roles of the authenticated principal or, when the policy trusts the
+// roles header, comma separated roles from request metadata of unauthenticated requests
+func (p *RedactionPolicy) roles(ctx context.Context) []string {
+	if pr, ok := auth.FromContext(ctx); ok {
+		return pr.Roles
+	}
+
+	if !p.TrustRolesHeader {
+		return nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	var rs []string
+	for _, v := range md.Get(p.RolesHeader) {
+		for _, r := range strings.Split(v, ",") {
+			if r = strings.TrimSpace(r); r != "" {
+				rs = append(rs, r)
+			}
+		}
+	}
+
+	return rs
+}
@@ -0,0 +1,188 @@
+package transport_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/cockroachdb/errors"
+	"github.com/nacobas/customer/auth"
+	"github.com/nacobas/customer/customer"
+	"github.com/nacobas/customer/pb"
+	"github.com/nacobas/customer/registry"
+	"github.com/nacobas/customer/repo/inmem"
+	"github.com/nacobas/customer/transport"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRedaction(t *testing.T) {
+	t.Parallel()
+
+	policy, err := transport.LoadRedactionPolicy("testdata/redaction.json")
+	assert.Nil(t, err, "policy should load")
+
+	svc := registry.NewService(inmem.NewRepoWithSeed(seed(t)))
+	gs := transport.NewGRPCServer(svc, transport.WithRedaction(policy))
+
+	testCases := []struct {
+		desc  string
+		roles string
+		// header are roles sent by the caller in request metadata
+		header string
+		id     uint32
+		ssn    string
+		dob    string
+		legal  string
+	}{
+		{
+			desc:  "kyc officer sees everything",
+			roles: "kyc-officer",
+			id:    1,
+			ssn:   "010170-123A",
+			dob:   "1970-01-01",
+		},
+		{
+			desc:  "call centre sees last four SSN characters",
+			roles: "call-centre",
+			id:    1,
+			ssn:   "*******123A",
+			dob:   "",
+		},
+		{
+			desc:  "least strict role wins",
+			roles: "call-centre,kyc-officer",
+			id:    1,
+			ssn:   "010170-123A",
+			dob:   "1970-01-01",
+		},
+		{
+			desc:  "unknown role gets default",
+			roles: "intern",
+			id:    1,
+		},
+		{
+			desc:  "call centre sees legal ID",
+			roles: "call-centre",
+			id:    2,
+			legal: "legal-id",
+		},
+		{
+			desc: "no roles gets default",
+			id:   2,
+		},
+		{
+			desc:   "roles header of unauthenticated caller is ignored",
+			header: "kyc-officer",
+			id:     1,
+		},
+		{
+			desc:   "roles header does not add to principal roles",
+			roles:  "call-centre",
+			header: "kyc-officer",
+			id:     1,
+			ssn:    "*******123A",
+		},
+		{
+			desc:  "call centre sees sole trader business ID",
+			roles: "call-centre",
+			id:    3,
+			ssn:   "*******456B",
+			legal: "7654321-0",
+		},
+		{
+			desc: "sole trader default",
+			id:   3,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := context.Background()
+			if tC.roles != "" {
+				ctx = auth.NewContext(ctx, &auth.Principal{Subject: "test", Roles: strings.Split(tC.roles, ",")})
+			}
+			if tC.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-roles", tC.header))
+			}
+
+			got, err := gs.Get(ctx, &pb.GetRequest{CustomerId: tC.id})
+			assert.Nil(t, err, "error should be nil")
+
+			if pi := got.GetCustomer().GetPersonInfo(); pi != nil {
+				assert.Equal(t, tC.ssn, pi.GetSsn(), "SSN")
+				assert.Equal(t, tC.dob, pi.GetDateOfBirth(), "date of birth")
+				assert.Equal(t, tC.dob != "", pi.GetBirthDate() != nil, "typed date of birth")
+			} else if si := got.GetCustomer().GetSoleTraderInfo(); si != nil {
+				assert.Equal(t, tC.ssn, si.GetSsn(), "SSN")
+				assert.Equal(t, tC.dob, si.GetDateOfBirth(), "date of birth")
+				assert.Equal(t, tC.dob != "", si.GetBirthDate() != nil, "typed date of birth")
+				assert.Equal(t, tC.legal, si.GetBusinessId(), "business ID")
+			} else {
+				assert.Equal(t, tC.legal, got.GetCustomer().GetOrganizationInfo().GetLegalId(), "legal ID")
+			}
+		})
+	}
+}
+
+func TestTrustedRolesHeader(t *testing.T) {
+	t.Parallel()
+
+	policy, err := transport.ParseRedactionPolicy([]byte(`{
+		"trust_roles_header": true,
+		"default": {"ssn": "hide"},
+		"roles": {"kyc-officer": {"ssn": "show"}}
+	}`))
+	assert.Nil(t, err, "policy should parse")
+
+	svc := registry.NewService(inmem.NewRepoWithSeed(seed(t)))
+	gs := transport.NewGRPCServer(svc, transport.WithRedaction(policy))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-roles", "kyc-officer"))
+	got, err := gs.Get(ctx, &pb.GetRequest{CustomerId: 1})
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, "010170-123A", got.GetCustomer().GetPersonInfo().GetSsn(), "trusted header roles should apply")
+}
+
+func TestParseRedactionPolicy(t *testing.T) {
+	t.Parallel()
+
+	_, err := transport.ParseRedactionPolicy([]byte(`{"default": {"ssn": "blur"}}`))
+	assert.True(t, errors.Is(err, transport.ErrInvalidPolicy), "unknown rule should be rejected")
+
+	_, err = transport.ParseRedactionPolicy([]byte(`{"default": {"phone": "hide"}}`))
+	assert.True(t, errors.Is(err, transport.ErrInvalidPolicy), "unknown field should be rejected")
+}
+
+func seed(t *testing.T) []customer.Customer {
+	return []customer.Customer{
+		{ID: 1, State: 1, Info: &customer.PersonInfo{
+			GivenName:   "given-name",
+			FamilyName:  "family-name",
+			SSN:         "010170-123A",
+			DateOfBirth: parseDate(t, "1970-01-01"),
+			Citizenship: "FI"}},
+		{ID: 2, State: 2, Info: &customer.OrganizationInfo{
+			Name:                "org-name",
+			Form:                "Ltd",
+			LeagalID:            "legal-id",
+			RegistrationDate:    parseDate(t, "1970-01-01"),
+			RegistrationCountry: "FI"}},
+		{ID: 3, State: 2, Info: &customer.SoleTraderInfo{
+			GivenName:   "given-name",
+			FamilyName:  "family-name",
+			SSN:         "020280-456B",
+			DateOfBirth: parseDate(t, "1980-02-02"),
+			Citizenship: "FI",
+			TradeName:   "trade-name",
+			BusinessID:  "7654321-0"}},
+	}
+}
+
+func parseDate(t *testing.T, datestr string) date.Date {
+	d, err := date.ParseDate(datestr)
+	if err != nil {
+		t.Fatalf("Failed to parse date from: %s, error: %v", datestr, err)
+	}
+	return d
+}
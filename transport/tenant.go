@@ -0,0 +1,73 @@
+package transport
+
+import (
+	"context"
+
+	"github.com/nacobas/customer/auth"
+	"github.com/nacobas/customer/tenant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TenantHeader is the request metadata key holding the tenant ID
+const TenantHeader = "x-tenant-id"
+
+// CrossTenantRole lets authenticated principals not bound to a tenant, such as a JWT without
+// a tenant claim or a client certificate without an organization, choose the tenant through
+// TenantHeader. Other principals without a tenant are rejected.
+const CrossTenantRole = "cross-tenant"
+
+// UnaryTenantInterceptor puts the tenant into the context. The tenant of an authenticated
+// principal takes precedence, a differing tenant in metadata is rejected. Principals without
+// a tenant need CrossTenantRole. It must run after UnaryAuthInterceptor.
+func UnaryTenantInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := resolveTenant(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func StreamTenantInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := resolveTenant(ss.Context())
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func resolveTenant(ctx context.Context) (context.Context, error) {
+	var requested string
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(TenantHeader); len(v) > 0 {
+		requested = v[0]
+	}
+
+	p, ok := auth.FromContext(ctx)
+	if !ok {
+		// authentication disabled
+		return tenant.NewContext(ctx, requested), nil
+	}
+
+	if p.Tenant == "" {
+		if !p.HasRole(CrossTenantRole) {
+			return ctx, status.Errorf(codes.PermissionDenied, "%s is not bound to a tenant", p.Subject)
+		}
+		return tenant.NewContext(ctx, requested), nil
+	}
+
+	if requested != "" && requested != p.Tenant {
+		return ctx, status.Errorf(codes.PermissionDenied, "%s is not a member of tenant %s", p.Subject, requested)
+	}
+
+	return tenant.NewContext(ctx, p.Tenant), nil
+}
@@ -0,0 +1,89 @@
+package transport_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nacobas/customer/auth"
+	"github.com/nacobas/customer/tenant"
+	"github.com/nacobas/customer/transport"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTenantInterceptor(t *testing.T) {
+	t.Parallel()
+
+	interceptor := transport.UnaryTenantInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return tenant.FromContext(ctx), nil
+	}
+
+	testCases := []struct {
+		desc      string
+		principal *auth.Principal
+		requested string
+		want      string
+		code      codes.Code
+	}{
+		{
+			desc:      "tenant of the principal",
+			principal: &auth.Principal{Subject: "agent", Tenant: "brand-a"},
+			want:      "brand-a",
+		},
+		{
+			desc:      "same tenant requested",
+			principal: &auth.Principal{Subject: "agent", Tenant: "brand-a"},
+			requested: "brand-a",
+			want:      "brand-a",
+		},
+		{
+			desc:      "other tenant requested",
+			principal: &auth.Principal{Subject: "agent", Tenant: "brand-a"},
+			requested: "brand-b",
+			code:      codes.PermissionDenied,
+		},
+		{
+			desc:      "principal without tenant",
+			principal: &auth.Principal{Subject: "agent"},
+			requested: "brand-b",
+			code:      codes.PermissionDenied,
+		},
+		{
+			desc:      "principal without tenant or request",
+			principal: &auth.Principal{Subject: "agent"},
+			code:      codes.PermissionDenied,
+		},
+		{
+			desc:      "cross-tenant role chooses the tenant",
+			principal: &auth.Principal{Subject: "ops", Roles: []string{transport.CrossTenantRole}},
+			requested: "brand-b",
+			want:      "brand-b",
+		},
+		{
+			desc:      "authentication disabled",
+			requested: "brand-b",
+			want:      "brand-b",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := context.Background()
+			if tC.requested != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(transport.TenantHeader, tC.requested))
+			}
+			if tC.principal != nil {
+				ctx = auth.NewContext(ctx, tC.principal)
+			}
+
+			got, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+			assert.Equal(t, tC.code, status.Code(err), "status code")
+			if tC.code == codes.OK {
+				assert.Equal(t, tC.want, got, "tenant")
+			}
+		})
+	}
+}